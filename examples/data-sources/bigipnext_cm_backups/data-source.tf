@@ -0,0 +1,5 @@
+data "bigipnext_cm_backups" "all" {}
+
+output "backup_files" {
+  value = [for backup in data.bigipnext_cm_backups.all.backups : backup.file_name]
+}
@@ -0,0 +1,11 @@
+data "bigipnext_cm_f5os_images" "rseries" {
+  provider_name = "myrseries"
+}
+
+output "tenant_images" {
+  value = data.bigipnext_cm_f5os_images.rseries.tenant_images[*].name
+}
+
+output "deployment_files" {
+  value = data.bigipnext_cm_f5os_images.rseries.deployment_files[*].name
+}
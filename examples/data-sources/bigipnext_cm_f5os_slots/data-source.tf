@@ -0,0 +1,11 @@
+data "bigipnext_cm_f5os_slots" "velos" {
+  provider_name = "myvelos"
+}
+
+output "free_slots" {
+  value = data.bigipnext_cm_f5os_slots.velos.free_slots
+}
+
+output "free_cpu_cores" {
+  value = { for slot in data.bigipnext_cm_f5os_slots.velos.slots : slot.slot => slot.cpu_cores_free }
+}
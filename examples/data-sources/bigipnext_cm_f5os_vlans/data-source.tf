@@ -0,0 +1,7 @@
+data "bigipnext_cm_f5os_vlans" "rseries" {
+  provider_name = "myrseries"
+}
+
+output "vlan_ids" {
+  value = data.bigipnext_cm_f5os_vlans.rseries.vlans[*].id
+}
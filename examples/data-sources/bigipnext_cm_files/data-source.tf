@@ -0,0 +1,7 @@
+# Files uploaded to Central Manager
+data "bigipnext_cm_files" "cm" {}
+
+# Files on a BIG-IP Next instance
+data "bigipnext_cm_files" "instance" {
+  instance_ip = "10.146.168.20"
+}
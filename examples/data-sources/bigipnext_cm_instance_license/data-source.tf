@@ -0,0 +1,8 @@
+data "bigipnext_cm_instance_license" "prod" {
+  hostnames          = ["prod-1", "prod-2"]
+  instance_addresses = ["10.10.10.21"]
+}
+
+output "unlicensed_instances" {
+  value = [for instance in data.bigipnext_cm_instance_license.prod.instances : instance.hostname if instance.license_status != "Active"]
+}
@@ -0,0 +1,5 @@
+data "bigipnext_cm_license_tokens" "all" {}
+
+output "expiring_tokens" {
+  value = [for token in data.bigipnext_cm_license_tokens.all.tokens : token.token_name if coalesce(token.days_until_expiry, 365) <= 30]
+}
@@ -0,0 +1,7 @@
+data "bigipnext_cm_providers" "vsphere" {
+  type = "VSPHERE"
+}
+
+output "vsphere_providers" {
+  value = [for provider in data.bigipnext_cm_providers.vsphere.providers : "${provider.name} (${provider.address})"]
+}
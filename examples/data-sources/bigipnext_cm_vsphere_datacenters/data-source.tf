@@ -0,0 +1,7 @@
+data "bigipnext_cm_vsphere_datacenters" "vcenter" {
+  provider_name = "myvsphere01"
+}
+
+output "datacenters" {
+  value = data.bigipnext_cm_vsphere_datacenters.vcenter.datacenters[*].name
+}
@@ -0,0 +1,8 @@
+data "bigipnext_cm_vsphere_templates" "library" {
+  provider_name   = "myvsphere01"
+  content_library = "Contentlibrary"
+}
+
+output "next_templates" {
+  value = [for template in data.bigipnext_cm_vsphere_templates.library.templates : template.name if template.type == "vm-template"]
+}
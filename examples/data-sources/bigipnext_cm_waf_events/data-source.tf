@@ -0,0 +1,9 @@
+data "bigipnext_cm_waf_events" "last_week" {
+  time_window_hours = 168
+  policy_names      = ["new_waf_policy"]
+  limit             = 50
+}
+
+output "blocked_requests" {
+  value = [for event in data.bigipnext_cm_waf_events.last_week.events : "${event.time} ${event.source_ip} ${event.url}" if event.request_status == "blocked"]
+}
@@ -0,0 +1,9 @@
+data "bigipnext_cm_waf_report_results" "weekly" {
+  report_name = "weekly_report"
+  top_n       = 5
+  categories  = ["Source IPs", "Attack Types"]
+}
+
+output "top_source_ips" {
+  value = [for entry in data.bigipnext_cm_waf_report_results.weekly.results[0].entries : "${entry.name}: ${entry.count}"]
+}
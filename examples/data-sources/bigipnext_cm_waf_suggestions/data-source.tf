@@ -0,0 +1,9 @@
+data "bigipnext_cm_waf_suggestions" "high_score" {
+  policy             = "new_waf_policy"
+  min_learning_score = 80
+  suggestion_type    = "url"
+}
+
+output "url_suggestions" {
+  value = [for suggestion in data.bigipnext_cm_waf_suggestions.high_score.suggestions : "${suggestion.id}: ${suggestion.description}"]
+}
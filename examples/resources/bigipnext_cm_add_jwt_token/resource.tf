@@ -1,4 +1,5 @@
 resource "bigipnext_cm_add_jwt_token" "tokenadd" {
-  token_name = "paid_test_jwt"
-  jwt_token  = "eyJhbG"
-}
\ No newline at end of file
+  token_name          = "paid_test_jwt"
+  jwt_token           = "eyJhbG"
+  expiry_warning_days = 30
+}
@@ -0,0 +1,21 @@
+# Instant backup of BIG-IP Next Central Manager
+resource "bigipnext_cm_backup" "test" {
+  name                = "Test"
+  encryption_password = "F5site02@1234"
+}
+
+# Instant backup archived to a S3 compatible bucket, keeping the last 7 archives
+resource "bigipnext_cm_backup" "archived" {
+  name                = "Archived"
+  encryption_password = "F5site02@1234"
+  archive = {
+    s3 = {
+      endpoint   = "https://s3.us-east-1.amazonaws.com"
+      bucket     = "bigip-next-backups"
+      prefix     = "cm/"
+      access_key = var.s3_access_key
+      secret_key = var.s3_secret_key
+    }
+    retention_count = 7
+  }
+}
@@ -0,0 +1,26 @@
+# Weekly backup of BIG-IP Next Central Manager
+resource "bigipnext_cm_backup_schedule" "weekly" {
+  name                    = "weekly"
+  encryption_password     = "F5site02@1234"
+  start_at                = "2025-08-25T18:30:00Z"
+  end_at                  = "2025-09-25T18:30:00Z"
+  frequency               = "Weekly"
+  days_of_the_week_to_run = [1, 2, 3, 4]
+}
+
+# Monthly backup of BIG-IP Next Central Manager
+resource "bigipnext_cm_backup_schedule" "monthly" {
+  name                    = "monthly"
+  encryption_password     = "F5site02@1234"
+  start_at                = "2025-08-25T18:30:00Z"
+  frequency               = "Monthly"
+  day_of_the_month_to_run = 10
+}
+
+# Daily backup of BIG-IP Next Central Manager
+resource "bigipnext_cm_backup_schedule" "daily" {
+  name                = "daily"
+  encryption_password = "F5site02@1234"
+  start_at            = "2025-08-25T18:30:00Z"
+  frequency           = "Daily"
+}
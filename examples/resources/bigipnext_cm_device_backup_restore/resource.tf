@@ -3,4 +3,25 @@ resource "bigipnext_cm_device_backup_restore" "sample" {
   operation       = "backup"
   file_name       = "test.tar.gz"
   device_hostname = "big-ip-next"
-}
\ No newline at end of file
+}
+
+# Instance backup downloaded to a local directory, keeping the last 5 archives
+resource "bigipnext_cm_device_backup_restore" "archived" {
+  backup_password = "F5site02@123"
+  operation       = "backup"
+  file_name       = "archived.tar.gz"
+  device_hostname = "big-ip-next"
+  archive = {
+    local_directory = "/var/backups/bigip-next"
+    retention_count = 5
+  }
+}
+
+# Disaster recovery: restore an instance from an archive kept outside of Central Manager
+resource "bigipnext_cm_device_backup_restore" "dr" {
+  backup_password  = "F5site02@123"
+  operation        = "restore"
+  file_name        = "dr-restore.tar.gz"
+  upload_file_path = "/var/backups/bigip-next/archived.tar.gz"
+  device_hostname  = "big-ip-next"
+}
@@ -0,0 +1,10 @@
+# Upload a BIG-IP Next image to Central Manager
+resource "bigipnext_cm_file" "next_image" {
+  file_path = "/var/tmp/BIG-IP-Next-20.3.0-2.713.1.tgz"
+}
+
+# Upload a signature file through Central Manager to a BIG-IP Next instance
+resource "bigipnext_cm_file" "next_signature" {
+  file_path   = "/var/tmp/BIG-IP-Next-20.3.0-2.713.1.tgz.512.sig"
+  instance_ip = "10.146.168.20"
+}
@@ -0,0 +1,10 @@
+# Back up all production instances running 20.3.0, 10 at a time
+resource "bigipnext_cm_fleet_backup" "nightly" {
+  backup_password  = "F5site02@123"
+  file_name_prefix = "nightly"
+  parallelism      = 10
+  filter = {
+    versions         = ["20.3.0-2.538.2"]
+    hostname_pattern = "^prod-"
+  }
+}
@@ -0,0 +1,2 @@
+# GSLB application can be imported by specifying the application ID.
+terraform import bigipnext_cm_gslb_application.webapp 5b0f6a7e-2c1d-4e3f-9a8b-7c6d5e4f3a21
@@ -0,0 +1,61 @@
+resource "bigipnext_cm_global_resiliency" "dns" {
+  name              = "dnsgroup"
+  dns_listener_name = "dnslistener"
+  dns_listener_port = 53
+  protocols         = ["udp"]
+  instances = [
+    {
+      address              = "10.145.71.115"
+      dns_listener_address = "2.2.2.3"
+      group_sync_address   = "10.10.1.2/24"
+      hostname             = "big-ip-next-dc1"
+    },
+    {
+      address              = "10.144.140.81"
+      dns_listener_address = "1.1.1.3"
+      group_sync_address   = "192.168.1.1/24"
+      hostname             = "big-ip-next-dc2"
+    }
+  ]
+}
+
+resource "bigipnext_cm_gslb_monitor" "http" {
+  name = "http_monitor"
+  type = "http"
+}
+
+resource "bigipnext_cm_gslb_application" "webapp" {
+  name        = "webapp"
+  description = "multi-datacenter web application"
+  gr_group_id = bigipnext_cm_global_resiliency.dns.id
+  fqdns = [
+    {
+      name                = "www.example.com"
+      load_balancing_mode = "global-availability"
+      ttl                 = 60
+      pools               = ["dc1_pool", "dc2_pool"]
+    }
+  ]
+  pools = [
+    {
+      name     = "dc1_pool"
+      monitors = [bigipnext_cm_gslb_monitor.http.name]
+      members = [
+        {
+          instance_address = "10.145.71.115"
+          virtual_server   = "webapp_vs"
+        }
+      ]
+    },
+    {
+      name     = "dc2_pool"
+      monitors = [bigipnext_cm_gslb_monitor.http.name]
+      members = [
+        {
+          instance_address = "10.144.140.81"
+          virtual_server   = "webapp_vs"
+        }
+      ]
+    }
+  ]
+}
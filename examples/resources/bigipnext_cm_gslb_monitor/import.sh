@@ -0,0 +1,2 @@
+# GSLB monitor can be imported by specifying the monitor ID.
+terraform import bigipnext_cm_gslb_monitor.http 8e2d4c6a-1b3f-4a5e-9c7d-0f1e2d3c4b5a
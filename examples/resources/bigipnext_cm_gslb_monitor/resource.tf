@@ -0,0 +1,8 @@
+resource "bigipnext_cm_gslb_monitor" "http" {
+  name     = "http_monitor"
+  type     = "http"
+  interval = 10
+  timeout  = 31
+  send     = "GET /health HTTP/1.0\r\n\r\n"
+  receive  = "200 OK"
+}
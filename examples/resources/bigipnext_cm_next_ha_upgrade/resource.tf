@@ -0,0 +1,12 @@
+# Rolling upgrade of a BIG-IP Next VE HA cluster
+resource "bigipnext_cm_next_ha_upgrade" "upgrade_ha" {
+  ha_ip              = "10.146.168.20"
+  image_name         = "BIG-IP-Next-20.3.0-2.713.1.tgz"
+  signature_filename = "BIG-IP-Next-20.3.0-2.713.1.tgz.512.sig"
+  timeout            = 3600
+  health_check = {
+    paths    = ["/systems"]
+    retries  = 10
+    interval = 30
+  }
+}
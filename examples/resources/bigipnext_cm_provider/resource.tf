@@ -1,9 +1,10 @@
 resource "bigipnext_cm_provider" "vpshere" {
-  name     = "testvpshereprovvm01"
-  address  = "xxx70-vcenter.f5.com"
-  type     = "VSPHERE"
-  username = "xxxxxxx"
-  password = "xxxxxxx"
+  name                  = "testvpshereprovvm01"
+  address               = "xxx70-vcenter.f5.com"
+  type                  = "VSPHERE"
+  username              = "xxxxxxx"
+  password              = "xxxxxxx"
+  validate_connectivity = true
 }
 resource "bigipnext_cm_provider" "rseries" {
   name     = "testrseriesprovvm01"
@@ -0,0 +1,8 @@
+data "bigipnext_cm_backups" "all" {}
+
+# Restore BIG-IP Next Central Manager from a backup file
+resource "bigipnext_cm_restore" "test" {
+  file_id             = data.bigipnext_cm_backups.all.backups[0].file_id
+  encryption_password = "F5site02@1234"
+  confirm_restore     = true
+}
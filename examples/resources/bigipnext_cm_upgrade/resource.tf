@@ -0,0 +1,6 @@
+# Upgrade BIG-IP Next Central Manager using a CM upgrade bundle
+resource "bigipnext_cm_upgrade" "upgrade_cm" {
+  upgrade_file_path = "/var/tmp/BIG-IP-Next-CentralManager-20.3.0-0.14.42-Update.tgz"
+  sha256_checksum   = "a240520a9fc7532de3786b82e0d4068357836e8ba7ca23098f8f4b28cc8f2573"
+  timeout           = 3600
+}
@@ -0,0 +1,3 @@
+# WAF Policy can be imported by specifying the policy ID or name.
+terraform import bigipnext_cm_waf_policy.test 1a4453fe-b37a-4212-a813-a3d2f789dad1
+terraform import bigipnext_cm_waf_policy.test new_waf_policy
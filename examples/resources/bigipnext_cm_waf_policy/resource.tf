@@ -5,4 +5,61 @@ resource "bigipnext_cm_waf_policy" "sample" {
   enforcement_mode     = "blocking"
   application_language = "utf-8"
   template_name        = "Rating-Based-Template"
-}
\ No newline at end of file
+}
+resource "bigipnext_cm_waf_policy" "application" {
+  name                 = "application_waf_policy"
+  enforcement_mode     = "blocking"
+  application_language = "utf-8"
+  template_name        = "Rating-Based-Template"
+  declaration_json     = file("${path.module}/policy_declaration.json")
+  urls = [
+    {
+      name     = "/login"
+      method   = "POST"
+      protocol = "https"
+    },
+  ]
+  parameters = [
+    {
+      name                = "password"
+      sensitive_parameter = true
+    },
+  ]
+  filetypes = [
+    {
+      name    = "exe"
+      allowed = false
+    },
+  ]
+  signature_sets = [
+    {
+      name = "High Accuracy Signatures"
+    },
+  ]
+  signatures = [
+    {
+      signature_id = 200001834
+      enabled      = false
+    },
+  ]
+  whitelist_ips = [
+    {
+      ip_address     = "10.10.10.0"
+      ip_mask        = "255.255.255.0"
+      block_requests = "never"
+    },
+  ]
+  cookies = [
+    {
+      name             = "session"
+      enforcement_type = "enforce"
+    },
+  ]
+  headers = [
+    {
+      name      = "x-api-key"
+      mandatory = true
+    },
+  ]
+  server_technologies = ["Apache Tomcat"]
+}
@@ -0,0 +1,12 @@
+resource "bigipnext_cm_waf_policy_attachment" "example" {
+  policy_name = bigipnext_cm_waf_policy.example.name
+  virtuals = [
+    {
+      document_id = bigipnext_cm_as3_deploy.example.id
+      tenant      = "DemoTenant3"
+      application = "TestApp33"
+      service     = "serviceMain"
+    }
+  ]
+  timeout = 600
+}
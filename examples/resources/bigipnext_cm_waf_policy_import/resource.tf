@@ -2,5 +2,26 @@ resource "bigipnext_cm_waf_policy_import" "sample" {
   name        = "new_waf_policy"
   description = "new_waf_policy desc"
   file_path   = "/Users/r.chinthalapalli/Downloads/testwaf5_awaf.json"
-  file_md5    = md5(file("/Users/r.chinthalapalli/Downloads/testwaf5_awaf.json"))
-}
\ No newline at end of file
+  export_path = "${path.module}/new_waf_policy_export.json"
+}
+
+resource "bigipnext_cm_waf_policy_import" "inline" {
+  name        = "inline_waf_policy"
+  description = "inline_waf_policy desc"
+  content = jsonencode({
+    policy = {
+      name                = "inline_waf_policy"
+      applicationLanguage = "utf-8"
+      enforcementMode     = "blocking"
+      template = {
+        name = "POLICY_TEMPLATE_RAPID_DEPLOYMENT"
+      }
+    }
+  })
+}
+
+resource "bigipnext_cm_waf_policy_import" "remote" {
+  name        = "remote_waf_policy"
+  description = "remote_waf_policy desc"
+  url         = "https://policies.example.com/waf/remote_waf_policy.xml"
+}
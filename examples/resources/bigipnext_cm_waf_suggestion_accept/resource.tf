@@ -0,0 +1,8 @@
+# suggestion IDs reviewed in a pull request, from the output of the bigipnext_cm_waf_suggestions data source
+resource "bigipnext_cm_waf_suggestion_accept" "reviewed" {
+  policy = "new_waf_policy"
+  suggestion_ids = [
+    "7d2ef4a0-6a0e-4a7e-9a1c-3f6c1c0e5b11",
+    "c5a1b2d3-1f2e-4c3d-8e9f-0a1b2c3d4e5f",
+  ]
+}
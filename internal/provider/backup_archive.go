@@ -0,0 +1,252 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type BackupArchiveModel struct {
+	LocalDirectory types.String `tfsdk:"local_directory"`
+	S3             types.Object `tfsdk:"s3"`
+	RetentionCount types.Int64  `tfsdk:"retention_count"`
+}
+
+type BackupArchiveS3Model struct {
+	Endpoint  types.String `tfsdk:"endpoint"`
+	Bucket    types.String `tfsdk:"bucket"`
+	Region    types.String `tfsdk:"region"`
+	Prefix    types.String `tfsdk:"prefix"`
+	AccessKey types.String `tfsdk:"access_key"`
+	SecretKey types.String `tfsdk:"secret_key"`
+}
+
+// backupArchiveSchema returns the schema of the archive attribute shared by the backup resources
+func backupArchiveSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "Copies the backup archive off the appliance once the backup is created, either to `local_directory` or to the `s3` endpoint." +
+			" The SHA-256 checksum of the archive is verified at the destination.",
+		Attributes: map[string]schema.Attribute{
+			"local_directory": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Local directory the archive is downloaded to, it is created when it does not exist.",
+			},
+			"s3": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "S3 compatible endpoint the archive is uploaded to.",
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "URL of the S3 compatible endpoint, for example `https://s3.us-east-1.amazonaws.com` or `http://minio.example.com:9000`.",
+					},
+					"bucket": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Name of the bucket.",
+					},
+					"region": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Region of the bucket, the default value is `us-east-1`.",
+					},
+					"prefix": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Prefix of the object key, for example `cm-backups/`.",
+					},
+					"access_key": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Access key ID.",
+					},
+					"secret_key": schema.StringAttribute{
+						Required:            true,
+						Sensitive:           true,
+						MarkdownDescription: "Secret access key.",
+					},
+				},
+			},
+			"retention_count": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Number of archives to keep at the destination, older archives named like the backups of this resource in `local_directory`" +
+					" or under the S3 `prefix` are deleted. Other files are kept. When not set no archives are deleted.",
+			},
+		},
+	}
+}
+
+// backupArchiveNaming is the naming of the archives of one backup resource, only archives with a name starting with
+// prefix and ending with suffix are pruned by retention_count
+type backupArchiveNaming struct {
+	prefix string
+	suffix string
+}
+
+func (n backupArchiveNaming) matches(name string) bool {
+	return strings.HasPrefix(name, n.prefix) && strings.HasSuffix(name, n.suffix)
+}
+
+// backupArchive copies the backup archive to the configured destination and returns the location and SHA-256 checksum
+// of the archive, when expectedSha256 is set the downloaded archive is verified against it
+func backupArchive(ctx context.Context, archive types.Object, fileName string, naming backupArchiveNaming, expectedSha256 string, download func(io.Writer) (int64, error)) (string, string, error) {
+	var archiveCfg BackupArchiveModel
+	if diags := archive.As(ctx, &archiveCfg, basetypes.ObjectAsOptions{}); diags.HasError() { // coverage-ignore
+		return "", "", fmt.Errorf("invalid archive configuration: %+v", diags.Errors())
+	}
+	if archiveCfg.LocalDirectory.IsNull() == archiveCfg.S3.IsNull() {
+		return "", "", fmt.Errorf("exactly one of archive.local_directory or archive.s3 must be set")
+	}
+	if !archiveCfg.RetentionCount.IsNull() && archiveCfg.RetentionCount.ValueInt64() < 1 {
+		return "", "", fmt.Errorf("archive.retention_count must be at least 1")
+	}
+
+	tmpFile, err := os.CreateTemp("", "bigipnext-backup-*")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.Remove(tmpFile.Name())
+	hash := sha256.New()
+	_, err = download(io.MultiWriter(tmpFile, hash))
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to download backup %s: %s", fileName, err)
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if expectedSha256 != "" && !strings.EqualFold(expectedSha256, checksum) {
+		return "", "", fmt.Errorf("checksum of downloaded backup %s is %s, expected %s", fileName, checksum, expectedSha256)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Downloaded backup %s with checksum %s", fileName, checksum))
+
+	var location string
+	if !archiveCfg.LocalDirectory.IsNull() {
+		location, err = archiveToLocalDirectory(ctx, archiveCfg, tmpFile.Name(), fileName, naming, checksum)
+	} else {
+		location, err = archiveToS3(ctx, archiveCfg, tmpFile.Name(), fileName, naming, checksum)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return location, checksum, nil
+}
+
+func archiveToLocalDirectory(ctx context.Context, archiveCfg BackupArchiveModel, tmpPath, fileName string, naming backupArchiveNaming, checksum string) (string, error) {
+	directory := archiveCfg.LocalDirectory.ValueString()
+	if err := os.MkdirAll(directory, 0750); err != nil {
+		return "", err
+	}
+	location := filepath.Join(directory, fileName)
+	if err := copyLocalFile(tmpPath, location); err != nil {
+		return "", err
+	}
+	archivedChecksum, err := fileSha256(location)
+	if err != nil {
+		return "", err
+	}
+	if archivedChecksum != checksum {
+		return "", fmt.Errorf("checksum of archived backup %s is %s, expected %s", location, archivedChecksum, checksum)
+	}
+	if archiveCfg.RetentionCount.IsNull() {
+		return location, nil
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return "", err
+	}
+	type archivedFile struct {
+		name    string
+		modTime int64
+	}
+	var archived []archivedFile
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || !naming.matches(entry.Name()) {
+			continue
+		}
+		archived = append(archived, archivedFile{name: entry.Name(), modTime: info.ModTime().UnixNano()})
+	}
+	sort.Slice(archived, func(i, j int) bool { return archived[i].modTime > archived[j].modTime })
+	for i := int(archiveCfg.RetentionCount.ValueInt64()); i < len(archived); i++ {
+		if archived[i].name == fileName {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Pruning archived backup %s", archived[i].name))
+		if err := os.Remove(filepath.Join(directory, archived[i].name)); err != nil {
+			return "", err
+		}
+	}
+	return location, nil
+}
+
+func archiveToS3(ctx context.Context, archiveCfg BackupArchiveModel, tmpPath, fileName string, naming backupArchiveNaming, checksum string) (string, error) {
+	var s3Cfg BackupArchiveS3Model
+	if diags := archiveCfg.S3.As(ctx, &s3Cfg, basetypes.ObjectAsOptions{}); diags.HasError() { // coverage-ignore
+		return "", fmt.Errorf("invalid archive.s3 configuration: %+v", diags.Errors())
+	}
+	region := "us-east-1"
+	if !s3Cfg.Region.IsNull() && s3Cfg.Region.ValueString() != "" {
+		region = s3Cfg.Region.ValueString()
+	}
+	client, err := newS3Client(s3Cfg.Endpoint.ValueString(), s3Cfg.Bucket.ValueString(), region, s3Cfg.AccessKey.ValueString(), s3Cfg.SecretKey.ValueString())
+	if err != nil {
+		return "", err
+	}
+	prefix := s3Cfg.Prefix.ValueString()
+	key := prefix + fileName
+	if err = client.putObject(key, tmpPath, checksum); err != nil {
+		return "", err
+	}
+	location := fmt.Sprintf("s3://%s/%s", s3Cfg.Bucket.ValueString(), key)
+	if archiveCfg.RetentionCount.IsNull() {
+		return location, nil
+	}
+
+	listed, err := client.listObjects(prefix)
+	if err != nil {
+		return "", err
+	}
+	objects := listed[:0]
+	for _, object := range listed {
+		if name := strings.TrimPrefix(object.Key, prefix); !strings.Contains(name, "/") && naming.matches(name) {
+			objects = append(objects, object)
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].LastModified.After(objects[j].LastModified) })
+	for i := int(archiveCfg.RetentionCount.ValueInt64()); i < len(objects); i++ {
+		if objects[i].Key == key {
+			continue
+		}
+		tflog.Info(ctx, fmt.Sprintf("Pruning archived backup s3://%s/%s", s3Cfg.Bucket.ValueString(), objects[i].Key))
+		if err := client.deleteObject(objects[i].Key); err != nil {
+			return "", err
+		}
+	}
+	return location, nil
+}
+
+func copyLocalFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -2,8 +2,11 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -19,8 +22,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &NextCMCertificateResource{}
-	_ resource.ResourceWithImportState = &NextCMCertificateResource{}
+	_ resource.Resource                   = &NextCMCertificateResource{}
+	_ resource.ResourceWithImportState    = &NextCMCertificateResource{}
+	_ resource.ResourceWithModifyPlan     = &NextCMCertificateResource{}
+	_ resource.ResourceWithValidateConfig = &NextCMCertificateResource{}
 	// mutex sync.Mutex
 )
 
@@ -56,6 +61,11 @@ type NextCMCertificateResourceModel struct {
 	KeyPassphrase          types.String `tfsdk:"key_passphrase"`
 	AdministratorEmail     types.String `tfsdk:"administrator_email"`
 	ChallengePassword      types.String `tfsdk:"challenge_password"`
+	EarlyRenewalDays       types.Int64  `tfsdk:"early_renewal_days"`
+	NotBefore              types.String `tfsdk:"not_before"`
+	NotAfter               types.String `tfsdk:"not_after"`
+	Serial                 types.String `tfsdk:"serial"`
+	Fingerprint            types.String `tfsdk:"fingerprint"`
 	Id                     types.String `tfsdk:"id"`
 }
 
@@ -65,7 +75,8 @@ func (r *NextCMCertificateResource) Metadata(ctx context.Context, req resource.M
 
 func (r *NextCMCertificateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used to manage(CRUD) certificate management resources onto BIG-IP Next CM.",
+		MarkdownDescription: "Resource used to manage(CRUD) certificate management resources onto BIG-IP Next CM.\n\n" +
+			"When `early_renewal_days` is set, the plan shows a renewal of the certificate once it expires within that number of days, the certificate is renewed on apply.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -165,6 +176,29 @@ func (r *NextCMCertificateResource) Schema(ctx context.Context, req resource.Sch
 				Optional:            true,
 				MarkdownDescription: "challenge password",
 			},
+			"early_renewal_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of days before `not_after` from which the certificate is renewed, less than `duration_in_days`. When not set the certificate is only renewed when its configuration changes.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"not_before": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time from which the certificate is valid.",
+			},
+			"not_after": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time until which the certificate is valid.",
+			},
+			"serial": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Serial number of the certificate.",
+			},
+			"fingerprint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Fingerprint of the certificate.",
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Unique Identifier for the resource",
@@ -180,6 +214,48 @@ func (r *NextCMCertificateResource) Configure(ctx context.Context, req resource.
 	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
 }
 
+// ValidateConfig checks early_renewal_days is less than duration_in_days, or the certificate would be renewed on every apply
+func (r *NextCMCertificateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var earlyRenewalDays, durationInDays types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("early_renewal_days"), &earlyRenewalDays)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("duration_in_days"), &durationInDays)...)
+	if resp.Diagnostics.HasError() || earlyRenewalDays.IsNull() || earlyRenewalDays.IsUnknown() || durationInDays.IsNull() || durationInDays.IsUnknown() {
+		return
+	}
+	if earlyRenewalDays.ValueInt64() >= durationInDays.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(path.Root("early_renewal_days"), "Invalid Early Renewal Days",
+			fmt.Sprintf("early_renewal_days (%d) must be less than duration_in_days (%d)", earlyRenewalDays.ValueInt64(), durationInDays.ValueInt64()))
+	}
+}
+
+// ModifyPlan plans the renewal of the certificate when it expires within early_renewal_days
+func (r *NextCMCertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	var planCfg, stateCfg *NextCMCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() || planCfg.EarlyRenewalDays.IsNull() || planCfg.EarlyRenewalDays.IsUnknown() { // coverage-ignore
+		return
+	}
+	notAfter, err := time.Parse(time.RFC3339, stateCfg.NotAfter.ValueString())
+	if err != nil {
+		return
+	}
+	renewAt := notAfter.AddDate(0, 0, -int(planCfg.EarlyRenewalDays.ValueInt64()))
+	if time.Now().Before(renewAt) {
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Certificate %s expires at %s, planning renewal", stateCfg.Name.ValueString(), stateCfg.NotAfter.ValueString()))
+	resp.Diagnostics.AddWarning("Certificate Renewal", fmt.Sprintf("Certificate %s expires at %s, which is within early_renewal_days, it will be renewed on apply.", stateCfg.Name.ValueString(), stateCfg.NotAfter.ValueString()))
+	planCfg.NotBefore = types.StringUnknown()
+	planCfg.NotAfter = types.StringUnknown()
+	planCfg.Serial = types.StringUnknown()
+	planCfg.Fingerprint = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, planCfg)...)
+}
+
 func (r *NextCMCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var resCfg *NextCMCertificateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
@@ -201,6 +277,12 @@ func (r *NextCMCertificateResource) Create(ctx context.Context, req resource.Cre
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] draftID:%+v\n", draftID))
 
 	resCfg.Id = types.StringValue(draftID)
+	certData, err := getNextCMCertificate(r.client, draftID)
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read Certificate, got error: %s", err))
+		return
+	}
+	certificateValidityToState(certData, resCfg)
 	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
 }
 
@@ -212,7 +294,7 @@ func (r *NextCMCertificateResource) Read(ctx context.Context, req resource.ReadR
 	}
 	id := stateCfg.Id.ValueString()
 	tflog.Info(ctx, fmt.Sprintf("Reading Certificate : %s", id))
-	certData, err := r.client.GetNextCMCertificate(id)
+	certData, err := getNextCMCertificate(r.client, id)
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read Certificate, got error: %s", err))
 		return
@@ -230,6 +312,60 @@ func (r *NextCMCertificateResource) CertificateResourceModeltoState(ctx context.
 	data.Issuer = types.StringValue(respData.(map[string]interface{})["issuer"].(string))
 	data.Name = types.StringValue(respData.(map[string]interface{})["name"].(string))
 	data.DurationInDays = types.Int64Value(int64(respData.(map[string]interface{})["duration_in_days"].(float64)))
+	certificateValidityToState(respData, data)
+}
+
+// getNextCMCertificate reads the certificate id like GetNextCMCertificate, with the serial number of the certificate
+// as a string: CM returns it as a JSON number or string, and a large number does not fit a float64
+func getNextCMCertificate(client *bigipnextsdk.BigipNextCM, id string) (interface{}, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("/v1/spaces/default/certificates/%s", id))
+	if err != nil {
+		return nil, err
+	}
+	certData := make(map[string]interface{})
+	if err := json.Unmarshal(respData, &certData); err != nil {
+		return nil, err
+	}
+	var serial struct {
+		Cert struct {
+			SerialNumber json.RawMessage `json:"serial_number"`
+		} `json:"cert"`
+	}
+	if err := json.Unmarshal(respData, &serial); err != nil { // coverage-ignore
+		return nil, err
+	}
+	if certInfo, ok := certData["cert"].(map[string]interface{}); ok && len(serial.Cert.SerialNumber) > 0 {
+		var serialNumber string
+		if err := json.Unmarshal(serial.Cert.SerialNumber, &serialNumber); err != nil {
+			serialNumber = string(serial.Cert.SerialNumber)
+		}
+		certInfo["serial_number"] = serialNumber
+	}
+	return certData, nil
+}
+
+// certificateValidityToState sets the validity, serial number and fingerprint of the certificate
+func certificateValidityToState(respData interface{}, data *NextCMCertificateResourceModel) {
+	data.NotBefore = types.StringNull()
+	data.NotAfter = types.StringNull()
+	data.Serial = types.StringNull()
+	data.Fingerprint = types.StringNull()
+	certInfo, ok := respData.(map[string]interface{})["cert"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if val, ok := certInfo["valid_from"].(string); ok {
+		data.NotBefore = types.StringValue(val)
+	}
+	if val, ok := certInfo["expiration_date_time"].(string); ok {
+		data.NotAfter = types.StringValue(val)
+	}
+	if val, ok := certInfo["serial_number"].(string); ok {
+		data.Serial = types.StringValue(val)
+	}
+	if val, ok := certInfo["fingerprint"].(string); ok {
+		data.Fingerprint = types.StringValue(val)
+	}
 }
 
 func (r *NextCMCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -252,7 +388,13 @@ func (r *NextCMCertificateResource) Update(ctx context.Context, req resource.Upd
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Update Certificate, got error: %s", err))
 		return
 	}
-	tflog.Info(ctx, fmt.Sprintf("[CREATE] draftID:%+v\n", draftID))
+	tflog.Info(ctx, fmt.Sprintf("[UPDATE] draftID:%+v\n", draftID))
+	certData, err := getNextCMCertificate(r.client, resCfg.Id.ValueString())
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read Certificate, got error: %s", err))
+		return
+	}
+	certificateValidityToState(certData, resCfg)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
 }
 
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+const uriAS3Documents = "/v1/spaces/default/appsvcs/documents"
+
+// as3Document is an application service document on CM and the instances it is deployed to
+type as3Document struct {
+	Id          string                  `json:"id,omitempty"`
+	Name        string                  `json:"name,omitempty"`
+	TenantName  string                  `json:"tenant_name,omitempty"`
+	Declaration map[string]interface{}  `json:"declaration,omitempty"`
+	Deployments []as3DocumentDeployment `json:"deployments,omitempty"`
+}
+
+type as3DocumentDeployment struct {
+	Id         string `json:"id,omitempty"`
+	InstanceId string `json:"instance_id,omitempty"`
+	Target     struct {
+		Address string `json:"address,omitempty"`
+	} `json:"target,omitempty"`
+}
+
+// getAS3Document returns the application service document with its declaration and deployments
+// /api/v1/spaces/default/appsvcs/documents/{document-id}
+func getAS3Document(client *bigipnextsdk.BigipNextCM, docId string) (*as3Document, error) {
+	respData, err := client.GetAS3DraftDocument(docId)
+	if err != nil {
+		return nil, err
+	}
+	var document as3Document
+	if err = json.Unmarshal(respData, &document); err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// updateAS3Document updates the declaration of the document and returns the ids of the deployment tasks
+// created by the update, one per instance the document is deployed to
+// /api/v1/spaces/default/appsvcs/documents/{document-id}
+func updateAS3Document(client *bigipnextsdk.BigipNextCM, docId string, declaration []byte) ([]string, error) {
+	respData, err := client.PutCMRequest(fmt.Sprintf("%s/%s", uriAS3Documents, docId), declaration)
+	if err != nil {
+		return nil, err
+	}
+	var document as3Document
+	if err = json.Unmarshal(respData, &document); err != nil {
+		return nil, err
+	}
+	var deployIds []string
+	for _, deployment := range document.Deployments {
+		deployIds = append(deployIds, deployment.Id)
+	}
+	return deployIds, nil
+}
+
+// getAS3DeploymentStatus returns the status and failure reason of the latest deployment task of the document to
+// an instance, without waiting for it to complete. The status is empty when the deployment has no task yet
+// /api/v1/spaces/default/appsvcs/documents/{document-id}/deployments/{deployment-id}
+func getAS3DeploymentStatus(client *bigipnextsdk.BigipNextCM, docId, deployId string) (string, string, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s/%s/deployments/%s", uriAS3Documents, docId, deployId))
+	if err != nil {
+		return "", "", err
+	}
+	var deployment struct {
+		Records []struct {
+			Status        string `json:"status"`
+			FailureReason string `json:"failure_reason"`
+		} `json:"records"`
+	}
+	if err = json.Unmarshal(respData, &deployment); err != nil {
+		return "", "", err
+	}
+	if len(deployment.Records) == 0 {
+		return "", "", nil
+	}
+	return deployment.Records[0].Status, deployment.Records[0].FailureReason, nil
+}
+
+// waitAS3Deployment polls the deployment of the document to an instance until it is completed or failed,
+// for up to timeOut seconds
+func waitAS3Deployment(client *bigipnextsdk.BigipNextCM, docId, deployId string, timeOut int) error {
+	timeout := time.Duration(timeOut) * time.Second
+	endtime := time.Now().Add(timeout)
+	var status string
+	for {
+		var failureReason string
+		var err error
+		status, failureReason, err = getAS3DeploymentStatus(client, docId, deployId)
+		if err != nil {
+			return err
+		}
+		switch status {
+		case "completed":
+			return nil
+		case "failed":
+			return fmt.Errorf("deployment %s of document %s failed: %s", deployId, docId, failureReason)
+		}
+		if !time.Now().Before(endtime) {
+			return fmt.Errorf("deployment %s of document %s is still in :%+v within timeout period of:%+v", deployId, docId, status, timeout)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
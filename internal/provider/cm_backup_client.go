@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+const (
+	uriCMBackups   = "/v1/system/backups"
+	uriNextBackups = "/device/v1/backups"
+)
+
+// cmBackupInfo is a CM backup file of the CM backup catalog
+type cmBackupInfo struct {
+	BackupType string `json:"backup_type,omitempty"`
+	CmVersion  string `json:"cm_version,omitempty"`
+	FileDate   string `json:"file_date,omitempty"`
+	FileId     string `json:"file_id,omitempty"`
+	FileName   string `json:"file_name,omitempty"`
+	FileSize   int64  `json:"file_size,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+type cmBackups struct {
+	Embedded struct {
+		Backups []cmBackupInfo `json:"backups,omitempty"`
+	} `json:"_embedded,omitempty"`
+}
+
+// getCMBackups returns the catalog of CM backup files
+// /api/v1/system/backups
+func getCMBackups(client *bigipnextsdk.BigipNextCM) (*cmBackups, error) {
+	respData, err := client.GetCMRequest(uriCMBackups)
+	if err != nil {
+		return nil, err
+	}
+	var backups cmBackups
+	if err = json.Unmarshal(respData, &backups); err != nil {
+		return nil, err
+	}
+	return &backups, nil
+}
+
+// getCMBackupByFileId returns the CM backup with given file ID, nil if there is no such backup
+func getCMBackupByFileId(client *bigipnextsdk.BigipNextCM, fileId string) (*cmBackupInfo, error) {
+	backups, err := getCMBackups(client)
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backups.Embedded.Backups {
+		if backup.FileId == fileId {
+			return &backup, nil
+		}
+	}
+	return nil, nil
+}
+
+// importNextBackupFile uploads a NEXT instance backup file kept outside of CM as fileName,
+// CM validates the backup password while importing the file
+// /api/device/v1/backups/import
+func importNextBackupFile(client *bigipnextsdk.BigipNextCM, filePath, fileName, password string) (*bigipnextsdk.TenantBackupFile, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	fields := map[string]string{
+		"file_name":     fileName,
+		"file_password": password,
+	}
+	if _, err = postCMMultipart(client, fmt.Sprintf("%s/import", uriNextBackups), fields, filepath.Base(filePath), file); err != nil {
+		return nil, err
+	}
+	return client.GetBackupFile(&fileName)
+}
+
+// downloadNextBackupFile streams the NEXT instance backup file with given name to w
+// /api/device/v1/backups/{name}/download
+func downloadNextBackupFile(client *bigipnextsdk.BigipNextCM, fileName string, w io.Writer) (int64, error) {
+	return downloadCMPath(client, fmt.Sprintf("%s/%s/download", uriNextBackups, fileName), w)
+}
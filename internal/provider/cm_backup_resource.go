@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource = &CMBackupResource{}
+)
+
+func NewCMBackupResource() resource.Resource {
+	return &CMBackupResource{}
+}
+
+type CMBackupResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type CMBackupResourceModel struct {
+	Name               types.String `tfsdk:"name"`
+	EncryptionPassword types.String `tfsdk:"encryption_password"`
+	FileName           types.String `tfsdk:"file_name"`
+	FileSize           types.Int64  `tfsdk:"file_size"`
+	Archive            types.Object `tfsdk:"archive"`
+	ArchiveLocation    types.String `tfsdk:"archive_location"`
+	ArchiveSha256      types.String `tfsdk:"archive_sha256"`
+	Id                 types.String `tfsdk:"id"`
+}
+
+func (r *CMBackupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_backup"
+}
+
+func (r *CMBackupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to create an instant backup of BIG-IP Next Central Manager.\n\n" +
+			"When doing `terraform destroy` the backup file is deleted from Central Manager.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the backup, the backup file name is generated by Central Manager.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encryption_password": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password used to encrypt the backup file, minimum of 8 characters.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(8),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the backup file generated by Central Manager.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"file_size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Size of the backup file in bytes.",
+			},
+			"archive": backupArchiveSchema(),
+			"archive_location": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Location the backup file was archived to, a local path or an `s3://` URL.",
+			},
+			"archive_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 checksum of the archived backup file.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "File ID of the backup, used as `file_id` of `bigipnext_cm_restore`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CMBackupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *CMBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *CMBackupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] CM Backup:%+v", resCfg.Name.ValueString()))
+
+	reqDraft := &bigipnextsdk.CMBackupRequestDraft{
+		EncryptionPassword: resCfg.EncryptionPassword.ValueString(),
+		Name:               resCfg.Name.ValueString(),
+		Type:               "light",
+	}
+	fileName, fileId, err := r.client.BackUpCM(reqDraft, "POST")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Create Backup, got error: %s", err))
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] Backup file:%+v id:%+v", fileName, fileId))
+
+	fileInfo, err := getCMFileInfo(r.client, fileName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read Backup, got error: %s", err))
+		return
+	}
+	resCfg.Id = types.StringValue(fileId)
+	resCfg.FileName = types.StringValue(fileName)
+	resCfg.FileSize = types.Int64Value(0)
+	if fileInfo != nil {
+		resCfg.FileSize = types.Int64Value(fileInfo.FileSize)
+	}
+	expectedSha256 := ""
+	if fileInfo != nil && len(fileInfo.Hash) == 64 {
+		expectedSha256 = fileInfo.Hash
+	}
+	if err = r.archiveBackup(ctx, resCfg, expectedSha256); err != nil {
+		// the backup exists on CM, keep it in state so that it is deleted on destroy
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Archive Backup, got error: %s", err))
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMBackupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	fileName := stateCfg.FileName.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Reading Backup : %s", fileName))
+
+	// the id is kept from Create, the files lookup only detects a deleted or replaced backup file
+	fileInfo, err := getCMFileInfo(r.client, fileName)
+	if err != nil && !strings.Contains(err.Error(), "\"code\":404") {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read Backup, got error: %s", err))
+		return
+	}
+	if fileInfo == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Backup file %s not found, removing it from state", fileName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if fileInfo.Id != stateCfg.Id.ValueString() {
+		tflog.Warn(ctx, fmt.Sprintf("Backup file %s was replaced by file %s, removing it from state", stateCfg.Id.ValueString(), fileInfo.Id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	stateCfg.FileSize = types.Int64Value(fileInfo.FileSize)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *CMBackupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg *CMBackupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	// all other configurable attributes require replacement, only the archive is updated in place
+	tflog.Info(ctx, fmt.Sprintf("[UPDATE] CM Backup archive:%+v", resCfg.FileName.ValueString()))
+	if err := r.archiveBackup(ctx, resCfg, ""); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Archive Backup, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+// cmBackupArchiveNaming matches the names Central Manager gives to its backup files, such as
+// Backup-20240827-105632_L_20.3.0-0.14.14_4.tgz
+var cmBackupArchiveNaming = backupArchiveNaming{prefix: "Backup-", suffix: ".tgz"}
+
+// archiveBackup copies the backup file to the configured archive destination and sets the archive attributes
+func (r *CMBackupResource) archiveBackup(ctx context.Context, resCfg *CMBackupResourceModel, expectedSha256 string) error {
+	resCfg.ArchiveLocation = types.StringNull()
+	resCfg.ArchiveSha256 = types.StringNull()
+	if resCfg.Archive.IsNull() {
+		return nil
+	}
+	fileId := resCfg.Id.ValueString()
+	location, checksum, err := backupArchive(ctx, resCfg.Archive, resCfg.FileName.ValueString(), cmBackupArchiveNaming, expectedSha256, func(w io.Writer) (int64, error) {
+		return downloadCMFile(r.client, fileId, w)
+	})
+	if err != nil {
+		return err
+	}
+	resCfg.ArchiveLocation = types.StringValue(location)
+	resCfg.ArchiveSha256 = types.StringValue(checksum)
+	return nil
+}
+
+func (r *CMBackupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *CMBackupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	id := stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Deleting Backup : %s", id))
+
+	if err := r.client.DeleteBackup(id, false); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to Delete Backup, got error: %s", err))
+		return
+	}
+	stateCfg.Id = types.StringValue("")
+}
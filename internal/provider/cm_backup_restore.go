@@ -57,7 +57,10 @@ func (r *CMBackupRestoreResource) Metadata(ctx context.Context, req resource.Met
 
 func (r *CMBackupRestoreResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used to manage Backup/Restore resources onto BIG-IP Next CM.",
+		MarkdownDescription: "Resource used to manage Backup/Restore resources onto BIG-IP Next CM.\n\n" +
+			"~> **NOTE** This resource is deprecated, use `bigipnext_cm_backup`, `bigipnext_cm_backup_schedule` and `bigipnext_cm_restore` instead.",
+		DeprecationMessage: "Use `bigipnext_cm_backup`, `bigipnext_cm_backup_schedule` and `bigipnext_cm_restore` instead, " +
+			"this resource will be removed in a future release.",
 		Attributes: map[string]schema.Attribute{
 			"encryption_password": schema.StringAttribute{
 				Required:            true,
@@ -357,54 +360,9 @@ func getCMBackupDraft(ctx context.Context, data *CMBackupRestoreResourceModel) (
 			tflog.Error(ctx, fmt.Sprintf("getCMBackupDraft diag Error: %+v", diag.Errors()))
 		}
 		scheduled = true
-		cmBackupConfig.Schedule.StartAt = providerModel.StartAt.ValueString()
-
-		if !providerModel.EndAt.IsNull() && !providerModel.EndAt.IsUnknown() {
-			cmBackupConfig.Schedule.EndAt = providerModel.EndAt.ValueString()
-		}
-
-		if data.Frequency.IsNull() || data.Frequency.IsUnknown() { // coverage-ignore
-			return nil, true, fmt.Errorf("Frequency should be provided in order to schedule a backup")
-		} else if data.Frequency == types.StringValue("Weekly") {
-
-			if data.DaysOfTheWeekToRun.IsNull() || data.DaysOfTheWeekToRun.IsUnknown() { // coverage-ignore
-				return nil, true, fmt.Errorf("Days of the week should be provided for Weekly Backup Schedule")
-			}
-
-			cmBackupConfig.ScheduleType = "DaysOfTheWeek"
-			daysoftheweekList := make([]int64, 0)
-			data.DaysOfTheWeekToRun.ElementsAs(ctx, &daysoftheweekList, false)
-			daysoftheweek := &bigipnextsdk.DaysOfTheWeek{}
-			daysoftheweek.HourToRunOn = 10
-			daysoftheweek.MinuteToRunOn = 30
-			daysoftheweek.Interval = 1
-			daysoftheweek.DaysOfTheWeekToRun = daysoftheweekList
-			cmBackupConfig.DaysOfTheWeek = daysoftheweek
-
-		} else if data.Frequency == types.StringValue("Monthly") {
-
-			if data.DayOfTheMonthToRun.IsNull() || data.DayOfTheMonthToRun.IsUnknown() { // coverage-ignore
-				return nil, true, fmt.Errorf("Day of the Month should be provided for Monthly Backup Schedule")
-			}
-
-			cmBackupConfig.ScheduleType = "DayAndTimeOfTheMonth"
-			dayandtimeofthemonth := &bigipnextsdk.DayAndTimeOfTheMonth{}
-			dayandtimeofthemonth.DayOfTheMonthToRun = int(data.DayOfTheMonthToRun.ValueInt64())
-			dayandtimeofthemonth.HourToRunOn = 10
-			dayandtimeofthemonth.Interval = 1
-			dayandtimeofthemonth.MinuteToRunOn = 30
-			cmBackupConfig.DayAndTimeOfTheMonth = dayandtimeofthemonth
-
-		} else if data.Frequency == types.StringValue("Daily") {
-			cmBackupConfig.ScheduleType = "BasicWithInterval"
-
-			basicwithinterval := &bigipnextsdk.BasicWithInterval{}
-			basicwithinterval.IntervalToRun = 24
-			basicwithinterval.IntervalUnit = "HOUR"
-
-			cmBackupConfig.BasicWithInterval = basicwithinterval
+		if err := setCMBackupSchedule(ctx, cmBackupConfig, providerModel.StartAt, providerModel.EndAt, data.Frequency, data.DaysOfTheWeekToRun, data.DayOfTheMonthToRun); err != nil {
+			return nil, true, err
 		}
-
 	} else {
 		scheduled = false
 		cmBackupConfig.Type = "light"
@@ -413,3 +371,55 @@ func getCMBackupDraft(ctx context.Context, data *CMBackupRestoreResourceModel) (
 	tflog.Info(ctx, fmt.Sprintf("getCMBackupDraft:%+v\n", cmBackupConfig))
 	return cmBackupConfig, scheduled, nil
 }
+
+// setCMBackupSchedule fills the schedule of the backup request for the given frequency
+func setCMBackupSchedule(ctx context.Context, cmBackupConfig *bigipnextsdk.CMBackupRequestDraft, startAt, endAt, frequency types.String, daysOfTheWeekToRun types.List, dayOfTheMonthToRun types.Int64) error {
+	cmBackupConfig.Schedule.StartAt = startAt.ValueString()
+
+	if !endAt.IsNull() && !endAt.IsUnknown() {
+		cmBackupConfig.Schedule.EndAt = endAt.ValueString()
+	}
+
+	if frequency.IsNull() || frequency.IsUnknown() { // coverage-ignore
+		return fmt.Errorf("Frequency should be provided in order to schedule a backup")
+	} else if frequency == types.StringValue("Weekly") {
+
+		if daysOfTheWeekToRun.IsNull() || daysOfTheWeekToRun.IsUnknown() { // coverage-ignore
+			return fmt.Errorf("Days of the week should be provided for Weekly Backup Schedule")
+		}
+
+		cmBackupConfig.ScheduleType = "DaysOfTheWeek"
+		daysoftheweekList := make([]int64, 0)
+		daysOfTheWeekToRun.ElementsAs(ctx, &daysoftheweekList, false)
+		daysoftheweek := &bigipnextsdk.DaysOfTheWeek{}
+		daysoftheweek.HourToRunOn = 10
+		daysoftheweek.MinuteToRunOn = 30
+		daysoftheweek.Interval = 1
+		daysoftheweek.DaysOfTheWeekToRun = daysoftheweekList
+		cmBackupConfig.DaysOfTheWeek = daysoftheweek
+
+	} else if frequency == types.StringValue("Monthly") {
+
+		if dayOfTheMonthToRun.IsNull() || dayOfTheMonthToRun.IsUnknown() { // coverage-ignore
+			return fmt.Errorf("Day of the Month should be provided for Monthly Backup Schedule")
+		}
+
+		cmBackupConfig.ScheduleType = "DayAndTimeOfTheMonth"
+		dayandtimeofthemonth := &bigipnextsdk.DayAndTimeOfTheMonth{}
+		dayandtimeofthemonth.DayOfTheMonthToRun = int(dayOfTheMonthToRun.ValueInt64())
+		dayandtimeofthemonth.HourToRunOn = 10
+		dayandtimeofthemonth.Interval = 1
+		dayandtimeofthemonth.MinuteToRunOn = 30
+		cmBackupConfig.DayAndTimeOfTheMonth = dayandtimeofthemonth
+
+	} else if frequency == types.StringValue("Daily") {
+		cmBackupConfig.ScheduleType = "BasicWithInterval"
+
+		basicwithinterval := &bigipnextsdk.BasicWithInterval{}
+		basicwithinterval.IntervalToRun = 24
+		basicwithinterval.IntervalUnit = "HOUR"
+
+		cmBackupConfig.BasicWithInterval = basicwithinterval
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource                = &CMBackupScheduleResource{}
+	_ resource.ResourceWithImportState = &CMBackupScheduleResource{}
+)
+
+// cmBackupScheduleFrequency maps the schedule types reported by CM to the frequency of the schedule
+var cmBackupScheduleFrequency = map[string]string{
+	"BASIC_WITH_INTERVAL":       "Daily",
+	"DAYS_OF_THE_WEEK":          "Weekly",
+	"DAY_AND_TIME_OF_THE_MONTH": "Monthly",
+}
+
+// cmBackupScheduleType maps the schedule types reported by CM to the schedule types of the request
+var cmBackupScheduleType = map[string]string{
+	"BASIC_WITH_INTERVAL":       "BasicWithInterval",
+	"DAYS_OF_THE_WEEK":          "DaysOfTheWeek",
+	"DAY_AND_TIME_OF_THE_MONTH": "DayAndTimeOfTheMonth",
+}
+
+func NewCMBackupScheduleResource() resource.Resource {
+	return &CMBackupScheduleResource{}
+}
+
+type CMBackupScheduleResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type CMBackupScheduleResourceModel struct {
+	Name               types.String `tfsdk:"name"`
+	EncryptionPassword types.String `tfsdk:"encryption_password"`
+	StartAt            types.String `tfsdk:"start_at"`
+	EndAt              types.String `tfsdk:"end_at"`
+	Frequency          types.String `tfsdk:"frequency"`
+	DaysOfTheWeekToRun types.List   `tfsdk:"days_of_the_week_to_run"`
+	DayOfTheMonthToRun types.Int64  `tfsdk:"day_of_the_month_to_run"`
+	Type               types.String `tfsdk:"type"`
+	NextRunTime        types.String `tfsdk:"next_run_time"`
+	Id                 types.String `tfsdk:"id"`
+}
+
+func (r *CMBackupScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_backup_schedule"
+}
+
+func (r *CMBackupScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to schedule recurring backups of BIG-IP Next Central Manager.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the backup schedule.",
+			},
+			"encryption_password": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password used to encrypt the backup files, minimum of 8 characters.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(8),
+				},
+			},
+			"start_at": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Start time of the schedule. Example: 2019-08-24T14:15:22Z",
+			},
+			"end_at": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "End time of the schedule. Example: 2019-08-24T14:15:22Z",
+			},
+			"frequency": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Frequency of the backups, one of `Daily`, `Weekly` or `Monthly`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf([]string{"Monthly", "Weekly", "Daily"}...),
+				},
+			},
+			"days_of_the_week_to_run": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Days of the week the backup runs on, 0-Sunday, 1-Monday and so on. Required when `frequency` is `Weekly`.",
+				Validators: []validator.List{
+					requiredIf(path.Root("frequency"), "Weekly"),
+				},
+			},
+			"day_of_the_month_to_run": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Day of the month the backup runs on. Required when `frequency` is `Monthly`.",
+				Validators: []validator.Int64{
+					requiredIf(path.Root("frequency"), "Monthly"),
+				},
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Schedule type sent to Central Manager: `BasicWithInterval`, `DaysOfTheWeek` or `DayAndTimeOfTheMonth`.",
+			},
+			"next_run_time": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time of the next scheduled backup reported by Central Manager.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the backup schedule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CMBackupScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *CMBackupScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *CMBackupScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] CM Backup Schedule:%+v", resCfg.Name.ValueString()))
+
+	reqDraft, err := getCMBackupScheduleDraft(ctx, resCfg)
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Create Backup Schedule, got error: %s", err))
+		return
+	}
+	_, scheduleId, err := r.client.BackUpCM(reqDraft, "POST")
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Create Backup Schedule, got error: %s", err))
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] Backup Schedule id:%+v", scheduleId))
+
+	resCfg.Id = types.StringValue(scheduleId)
+	resCfg.Type = types.StringValue(reqDraft.ScheduleType)
+	resp.Diagnostics.Append(r.readSchedule(ctx, resCfg)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMBackupScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMBackupScheduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	scheduleConfig, err := r.client.GetBackUpConfig(stateCfg.Id.ValueString(), true, false)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Warn(ctx, fmt.Sprintf("Backup Schedule %s not found, removing it from state", stateCfg.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read Backup Schedule, got error: %s", err))
+		return
+	}
+	cmBackupScheduleToState(ctx, scheduleConfig.(map[string]interface{}), stateCfg)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *CMBackupScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg *CMBackupScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[UPDATE] CM Backup Schedule:%+v", resCfg.Id.ValueString()))
+
+	reqDraft, err := getCMBackupScheduleDraft(ctx, resCfg)
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Update Backup Schedule, got error: %s", err))
+		return
+	}
+	reqDraft.Id = resCfg.Id.ValueString()
+	if _, _, err = r.client.BackUpCM(reqDraft, "PUT"); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Update Backup Schedule, got error: %s", err))
+		return
+	}
+	resCfg.Type = types.StringValue(reqDraft.ScheduleType)
+	resp.Diagnostics.Append(r.readSchedule(ctx, resCfg)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMBackupScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *CMBackupScheduleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	id := stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Deleting Backup Schedule : %s", id))
+
+	if err := r.client.DeleteBackup(id, true); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to Delete Backup Schedule, got error: %s", err))
+		return
+	}
+	stateCfg.Id = types.StringValue("")
+}
+
+func (r *CMBackupScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// readSchedule sets the values reported by CM for the schedule that was just created or updated
+func (r *CMBackupScheduleResource) readSchedule(ctx context.Context, data *CMBackupScheduleResourceModel) (diags diag.Diagnostics) {
+	scheduleConfig, err := r.client.GetBackUpConfig(data.Id.ValueString(), true, false)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to Read Backup Schedule, got error: %s", err))
+		return
+	}
+	data.NextRunTime = types.StringValue("")
+	if nextRunTime, ok := scheduleConfig.(map[string]interface{})["next_run_time"].(string); ok {
+		data.NextRunTime = types.StringValue(nextRunTime)
+	}
+	return
+}
+
+func getCMBackupScheduleDraft(ctx context.Context, data *CMBackupScheduleResourceModel) (*bigipnextsdk.CMBackupRequestDraft, error) {
+	cmBackupConfig := &bigipnextsdk.CMBackupRequestDraft{
+		EncryptionPassword: data.EncryptionPassword.ValueString(),
+		Name:               data.Name.ValueString(),
+	}
+	if err := setCMBackupSchedule(ctx, cmBackupConfig, data.StartAt, data.EndAt, data.Frequency, data.DaysOfTheWeekToRun, data.DayOfTheMonthToRun); err != nil {
+		return nil, err
+	}
+	tflog.Info(ctx, fmt.Sprintf("getCMBackupScheduleDraft:%+v\n", cmBackupConfig))
+	return cmBackupConfig, nil
+}
+
+// cmBackupScheduleToState sets the schedule attributes that CM reports as configured, start time is
+// only read back on import as CM reports the time of the first run instead
+func cmBackupScheduleToState(ctx context.Context, respData map[string]interface{}, data *CMBackupScheduleResourceModel) {
+	tflog.Info(ctx, fmt.Sprintf("cmBackupScheduleToState:%+v", respData))
+
+	if name, ok := respData["name"].(string); ok && !strings.EqualFold(name, data.Name.ValueString()) {
+		data.Name = types.StringValue(name)
+	}
+	if startDate, ok := respData["start_date"].(string); ok && data.StartAt.IsNull() {
+		data.StartAt = types.StringValue(startDate)
+	}
+	if endDate, ok := respData["end_date"].(string); ok {
+		data.EndAt = types.StringValue(endDate)
+	} else {
+		data.EndAt = types.StringNull()
+	}
+	if nextRunTime, ok := respData["next_run_time"].(string); ok {
+		data.NextRunTime = types.StringValue(nextRunTime)
+	}
+	scheduleType := fmt.Sprintf("%v", respData["schedule_type"])
+	if frequency, ok := cmBackupScheduleFrequency[scheduleType]; ok {
+		data.Frequency = types.StringValue(frequency)
+		data.Type = types.StringValue(cmBackupScheduleType[scheduleType])
+	}
+	if daysOfTheWeek, ok := respData["days_of_the_week"].(map[string]interface{}); ok {
+		data.DaysOfTheWeekToRun, _ = types.ListValueFrom(ctx, types.Int64Type, daysOfTheWeek["days_of_the_week_to_run"])
+	} else {
+		data.DaysOfTheWeekToRun = types.ListNull(types.Int64Type)
+	}
+	if dayAndTimeOfMonth, ok := respData["day_and_time_of_month"].(map[string]interface{}); ok {
+		data.DayOfTheMonthToRun = types.Int64Value(int64(dayAndTimeOfMonth["day_of_the_month_to_run"].(float64)))
+	} else {
+		data.DayOfTheMonthToRun = types.Int64Null()
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMBackupsDataSource{}
+)
+
+func NewCMBackupsDataSource() datasource.DataSource {
+	return &CMBackupsDataSource{}
+}
+
+// CMBackupsDataSource defines the data source implementation.
+type CMBackupsDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMBackupsDataSourceModel describes the data source data model.
+type CMBackupsDataSourceModel struct {
+	ID      types.String          `tfsdk:"id"`
+	Backups []CMBackupSourceModel `tfsdk:"backups"`
+}
+
+type CMBackupSourceModel struct {
+	FileId     types.String `tfsdk:"file_id"`
+	FileName   types.String `tfsdk:"file_name"`
+	FileSize   types.Int64  `tfsdk:"file_size"`
+	FileDate   types.String `tfsdk:"file_date"`
+	BackupType types.String `tfsdk:"backup_type"`
+	CMVersion  types.String `tfsdk:"cm_version"`
+	Status     types.String `tfsdk:"status"`
+}
+
+func (d *CMBackupsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_backups"
+}
+
+func (d *CMBackupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get information about the backup files of BIG-IP Next Central Manager.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this data source.",
+			},
+			"backups": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of backup files.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"file_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "File ID of the backup, used as `file_id` of `bigipnext_cm_restore`.",
+						},
+						"file_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the backup file.",
+						},
+						"file_size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size of the backup file in bytes.",
+						},
+						"file_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Creation time of the backup file.",
+						},
+						"backup_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the backup, such as `light`.",
+						},
+						"cm_version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Central Manager version the backup was taken on.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Status of the backup.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CMBackupsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMBackupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMBackupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	backups, err := getCMBackups(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read CM backups, got error: %s", err))
+		return
+	}
+	data.Backups = []CMBackupSourceModel{}
+	for _, backup := range backups.Embedded.Backups {
+		data.Backups = append(data.Backups, CMBackupSourceModel{
+			FileId:     types.StringValue(backup.FileId),
+			FileName:   types.StringValue(backup.FileName),
+			FileSize:   types.Int64Value(backup.FileSize),
+			FileDate:   types.StringValue(backup.FileDate),
+			BackupType: types.StringValue(backup.BackupType),
+			CMVersion:  types.StringValue(backup.CmVersion),
+			Status:     types.StringValue(backup.Status),
+		})
+	}
+	data.ID = types.StringValue("cm_backups")
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
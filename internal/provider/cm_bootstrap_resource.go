@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -20,8 +21,8 @@ import (
 )
 
 var (
-	_ resource.Resource
-	_ resource.ResourceWithImportState
+	_ resource.Resource                   = &CMNextBootstrapResource{}
+	_ resource.ResourceWithValidateConfig = &CMNextBootstrapResource{}
 )
 
 func NewCMNextBootstrapResource() resource.Resource {
@@ -42,11 +43,23 @@ type ExternalStorage struct {
 }
 
 type CMNextBootstrapResourceModel struct {
-	Id               types.String `tfsdk:"id"`
-	RunSetup         types.Bool   `tfsdk:"run_setup"`
-	ExternalStorage  types.Object `tfsdk:"external_storage"`
-	BootstrapStatus  types.String `tfsdk:"bootstrap_status"`
-	BootstrapTimeout types.Int64  `tfsdk:"bootstrap_timeout"`
+	Id                            types.String `tfsdk:"id"`
+	RunSetup                      types.Bool   `tfsdk:"run_setup"`
+	ExternalStorage               types.Object `tfsdk:"external_storage"`
+	BootstrapStatus               types.String `tfsdk:"bootstrap_status"`
+	BootstrapStep                 types.String `tfsdk:"bootstrap_step"`
+	BootstrapTimeout              types.Int64  `tfsdk:"bootstrap_timeout"`
+	ExternalStorageStatus         types.String `tfsdk:"external_storage_status"`
+	ExternalStorageFailureMessage types.String `tfsdk:"external_storage_failure_message"`
+}
+
+var externalStorageAttrTypes = map[string]attr.Type{
+	"storage_type":    types.StringType,
+	"storage_address": types.StringType,
+	"storage_path":    types.StringType,
+	"cm_storage_dir":  types.StringType,
+	"username":        types.StringType,
+	"password":        types.StringType,
 }
 
 func (r *CMNextBootstrapResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,7 +69,9 @@ func (r *CMNextBootstrapResource) Metadata(ctx context.Context, req resource.Met
 func (r *CMNextBootstrapResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Resource used for bootstrapping Central Manager\n\n" +
-			"~> **NOTE** This resource does not support update and delete. When doing `terraform destroy` it will only remove the resource from the state",
+			"Changing `external_storage` reconfigures the external storage of Central Manager, for example to rotate the SAMBA credentials. " +
+			"Setting `run_setup` to `true` runs the setup if it has not completed yet.\n\n" +
+			"~> **NOTE** This resource does not support delete. When doing `terraform destroy` it will only remove the resource from the state",
 		Attributes: map[string]schema.Attribute{
 			"run_setup": schema.BoolAttribute{
 				Required:            true,
@@ -88,10 +103,17 @@ func (r *CMNextBootstrapResource) Schema(ctx context.Context, req resource.Schem
 					"username": schema.StringAttribute{
 						MarkdownDescription: "Username to access the external storage, required if storage type is SAMBA",
 						Optional:            true,
+						Validators: []validator.String{
+							requiredIf(path.Root("external_storage").AtName("storage_type"), "SAMBA"),
+						},
 					},
 					"password": schema.StringAttribute{
 						MarkdownDescription: "Password to access the external storage, required if storage type is SAMBA",
 						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							requiredIf(path.Root("external_storage").AtName("storage_type"), "SAMBA"),
+						},
 					},
 				},
 			},
@@ -103,6 +125,18 @@ func (r *CMNextBootstrapResource) Schema(ctx context.Context, req resource.Schem
 				Computed:            true,
 				MarkdownDescription: "Status of the bootstrap operation",
 			},
+			"bootstrap_step": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Current step of the bootstrap operation",
+			},
+			"external_storage_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Setup status of the external storage, for example `SUCCESSFUL`",
+			},
+			"external_storage_failure_message": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Reason the external storage setup failed, empty when the setup is successful",
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "ID of the resource",
@@ -116,6 +150,28 @@ func (r *CMNextBootstrapResource) Configure(ctx context.Context, req resource.Co
 	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
 }
 
+func (r *CMNextBootstrapResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var externalStorage types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("external_storage"), &externalStorage)...)
+	if resp.Diagnostics.HasError() || externalStorage.IsNull() || externalStorage.IsUnknown() {
+		return
+	}
+	var externalStorageModel ExternalStorage
+	resp.Diagnostics.Append(externalStorage.As(ctx, &externalStorageModel, basetypes.ObjectAsOptions{UnhandledUnknownAsEmpty: true})...)
+	if resp.Diagnostics.HasError() || externalStorageModel.StorageType.ValueString() != "NFS" {
+		return
+	}
+	// NFS shares are mounted without credentials, the SAMBA fields are required by the validators of the attributes
+	for _, name := range []string{"username", "password"} {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("external_storage").AtName(name), &value)...)
+		if !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("external_storage").AtName(name), "Invalid Attribute Combination",
+				fmt.Sprintf("Attribute external_storage.%s is only supported when external_storage.storage_type is set to SAMBA", name))
+		}
+	}
+}
+
 func (r *CMNextBootstrapResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var resCfg CMNextBootstrapResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
@@ -124,173 +180,228 @@ func (r *CMNextBootstrapResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	resCfg.ExternalStorageStatus = types.StringNull()
+	resCfg.ExternalStorageFailureMessage = types.StringNull()
 	if !resCfg.ExternalStorage.IsNull() {
-		var externalStorageModel ExternalStorage
-		diag := resCfg.ExternalStorage.As(ctx, &externalStorageModel, basetypes.ObjectAsOptions{})
-
-		if diag.HasError() {
+		resp.Diagnostics.Append(r.setupExternalStorage(ctx, &resCfg)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+	}
 
-		externalStorage := getExternalStorageData(&externalStorageModel)
-		res, err := r.client.AddExternalStorage(externalStorage)
-		if err != nil { // coverage-ignore
-			resp.Diagnostics.AddError("Failed to add external storage:", err.Error())
+	resCfg.BootstrapStatus = types.StringValue("")
+	resCfg.BootstrapStep = types.StringValue("")
+	if resCfg.RunSetup.ValueBool() {
+		resp.Diagnostics.Append(r.runBootstrap(ctx, &resCfg)...)
+		if resp.Diagnostics.HasError() { // coverage-ignore
 			return
 		}
+	}
+
+	id := extractIPFromUrl(r.client.Host)
+
+	resCfg.Id = types.StringValue(fmt.Sprintf("setup-%s", id))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
+}
 
-		externalStoreResp := &bigipnextsdk.CMExternalStorageResp{}
+func (r *CMNextBootstrapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMNextBootstrapResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		tflog.Info(ctx, "External storage setup response: "+res)
-		err = json.Unmarshal([]byte(res), externalStoreResp)
+	if !stateCfg.ExternalStorage.IsNull() {
+		res, err := r.client.GetCMExternalStorage()
 		if err != nil { // coverage-ignore
-			resp.Diagnostics.AddError("Failed to unmarshal external storage response:", err.Error())
+			resp.Diagnostics.AddError("Failed to read external storage status", err.Error())
 			return
 		}
-
-		if externalStoreResp.Status.Setup != "SUCCESSFUL" { // coverage-ignore
-			tflog.Error(ctx, "Failed to setup external storage")
-			resp.Diagnostics.AddError("Failed to setup external storage", externalStoreResp.Status.FailureMessage)
+		externalStorageResp := &bigipnextsdk.CMExternalStorageResp{}
+		err = json.Unmarshal([]byte(res), externalStorageResp)
+		if err != nil { // coverage-ignore
+			resp.Diagnostics.AddError("Failed to unmarshal external storage response", err.Error())
 			return
 		}
+		resp.Diagnostics.Append(externalStorageToState(ctx, externalStorageResp, stateCfg)...)
+	}
 
-		typeMap := map[string]attr.Type{
-			"storage_type":    types.StringType,
-			"storage_address": types.StringType,
-			"storage_path":    types.StringType,
-			"cm_storage_dir":  types.StringType,
-			"username":        types.StringType,
-			"password":        types.StringType,
-		}
-		valMap := map[string]attr.Value{
-			"storage_type":    types.StringValue(externalStoreResp.Spec.StorageType),
-			"storage_address": types.StringValue(externalStoreResp.Spec.StorageAddress),
-			"storage_path":    types.StringValue(externalStoreResp.Spec.StorageSharePath),
-		}
+	bootstrap, err := r.client.GetCMBootstrap()
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Failed to read the bootstrap status", err.Error())
+		return
+	}
+	bootstrapResp := &bigipnextsdk.BootstrapCMResp{}
+	err = json.Unmarshal([]byte(bootstrap), bootstrapResp)
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Failed to unmarshal the bootstrap response", err.Error())
+		return
+	}
 
-		if externalStoreResp.Spec.StorageType == "SAMBA" {
-			valMap["username"] = types.StringValue(externalStoreResp.Spec.StorageUser.Username)
-			valMap["password"] = types.StringValue(externalStoreResp.Spec.StorageUser.Password)
-		} else {
-			valMap["username"] = types.StringNull()
-			valMap["password"] = types.StringNull()
-		}
-		if externalStoreResp.Spec.StorageShareDir != "" {
-			valMap["cm_storage_dir"] = types.StringValue(externalStoreResp.Spec.StorageShareDir)
-		} else {
-			if !externalStorageModel.CMStorageDir.IsNull() {
-				valMap["cm_storage_dir"] = externalStorageModel.CMStorageDir
-			} else {
-				valMap["cm_storage_dir"] = types.StringNull()
-			}
-		}
+	id := extractIPFromUrl(r.client.Host)
 
-		resCfg.ExternalStorage, _ = types.ObjectValue(typeMap, valMap)
-	}
+	stateCfg.Id = types.StringValue(fmt.Sprintf("setup-%s", id))
+	stateCfg.BootstrapStatus = types.StringValue(bootstrapResp.Status)
+	stateCfg.BootstrapStep = types.StringValue(bootstrapResp.Step)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
 
-	var cmBootstrapStatus string
-	if resCfg.RunSetup.ValueBool() {
-		var timeout int64
-		if resCfg.BootstrapTimeout.IsNull() {
-			timeout = 600
-		} else { // coverage-ignore
-			timeout = resCfg.BootstrapTimeout.ValueInt64()
-		}
-		res, err := r.client.BootstrapCM(timeout)
+func (r *CMNextBootstrapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg, stateCfg CMNextBootstrapResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
 
-		if err != nil { // coverage-ignore
-			resp.Diagnostics.AddError("Failed to bootstrap Central Manager:", err.Error())
+	resCfg.ExternalStorageStatus = stateCfg.ExternalStorageStatus
+	resCfg.ExternalStorageFailureMessage = stateCfg.ExternalStorageFailureMessage
+	if !resCfg.ExternalStorage.IsNull() && !resCfg.ExternalStorage.Equal(stateCfg.ExternalStorage) {
+		tflog.Info(ctx, "[UPDATE] Reconfiguring external storage")
+		resp.Diagnostics.Append(r.setupExternalStorage(ctx, &resCfg)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+	} else if resCfg.ExternalStorage.IsNull() && !stateCfg.ExternalStorage.IsNull() {
+		// CM provides no API to remove the external storage, it is only removed from the state
+		tflog.Warn(ctx, "external_storage removed from the configuration, the external storage of Central Manager is not changed")
+		resCfg.ExternalStorageStatus = types.StringNull()
+		resCfg.ExternalStorageFailureMessage = types.StringNull()
+	}
 
-		bootStrapResp := &bigipnextsdk.BootstrapCMResp{}
-		err = json.Unmarshal([]byte(res), bootStrapResp)
-		if err != nil { // coverage-ignore
-			resp.Diagnostics.AddError("Failed to unmarshal Central Manager bootstrap response:", err.Error())
+	resCfg.BootstrapStatus = stateCfg.BootstrapStatus
+	resCfg.BootstrapStep = stateCfg.BootstrapStep
+	if resCfg.RunSetup.ValueBool() && stateCfg.BootstrapStatus.ValueString() != "COMPLETED" {
+		tflog.Info(ctx, "[UPDATE] Running Central Manager setup")
+		resp.Diagnostics.Append(r.runBootstrap(ctx, &resCfg)...)
+		if resp.Diagnostics.HasError() { // coverage-ignore
 			return
 		}
-
-		cmBootstrapStatus = bootStrapResp.Status
-
-		tflog.Info(ctx, "Central Manager bootstrap response: "+res)
 	}
 
-	id := extractIPFromUrl(r.client.Host)
-
-	resCfg.Id = types.StringValue(fmt.Sprintf("setup-%s", id))
-	resCfg.BootstrapStatus = types.StringValue(cmBootstrapStatus)
+	resCfg.Id = stateCfg.Id
 	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
 }
 
-func (r *CMNextBootstrapResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var stateCfg *CMNextBootstrapResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
-	if resp.Diagnostics.HasError() {
-		return
+func (r *CMNextBootstrapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Info(ctx, "this resource does not support delete operation, it can oly be removed from the state")
+	resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(""))
+}
+
+// setupExternalStorage configures the external storage of the plan on CM and sets the external storage attributes
+func (r *CMNextBootstrapResource) setupExternalStorage(ctx context.Context, resCfg *CMNextBootstrapResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var externalStorageModel ExternalStorage
+	diags.Append(resCfg.ExternalStorage.As(ctx, &externalStorageModel, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() { // coverage-ignore
+		return diags
 	}
 
-	res, err := r.client.GetCMExternalStorage()
+	externalStorage := getExternalStorageData(&externalStorageModel)
+	res, err := r.client.AddExternalStorage(externalStorage)
 	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError("Failed to read external storage status", err.Error())
+		diags.AddError("Failed to add external storage:", err.Error())
+		return diags
 	}
-	externalStorageResp := &bigipnextsdk.CMExternalStorageResp{}
-	err = json.Unmarshal([]byte(res), externalStorageResp)
+
+	externalStoreResp := &bigipnextsdk.CMExternalStorageResp{}
+
+	tflog.Info(ctx, "External storage setup response: "+res)
+	err = json.Unmarshal([]byte(res), externalStoreResp)
 	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError("Failed to unmarshal external storage response", err.Error())
+		diags.AddError("Failed to unmarshal external storage response:", err.Error())
+		return diags
 	}
 
-	typeMap := map[string]attr.Type{
-		"storage_type":    types.StringType,
-		"storage_address": types.StringType,
-		"storage_path":    types.StringType,
-		"cm_storage_dir":  types.StringType,
-		"username":        types.StringType,
-		"password":        types.StringType,
+	if externalStoreResp.Status.Setup != "SUCCESSFUL" {
+		tflog.Error(ctx, "Failed to setup external storage")
+		diags.AddError("Failed to setup external storage", externalStoreResp.Status.FailureMessage)
+		return diags
 	}
+
 	valMap := map[string]attr.Value{
-		"storage_type":    types.StringValue(externalStorageResp.Spec.StorageType),
-		"storage_address": types.StringValue(externalStorageResp.Spec.StorageAddress),
-		"storage_path":    types.StringValue(externalStorageResp.Spec.StorageSharePath),
+		"storage_type":    types.StringValue(externalStoreResp.Spec.StorageType),
+		"storage_address": types.StringValue(externalStoreResp.Spec.StorageAddress),
+		"storage_path":    types.StringValue(externalStoreResp.Spec.StorageSharePath),
+		"username":        externalStorageModel.Username,
+		"password":        externalStorageModel.Password,
 	}
 
-	if externalStorageResp.Spec.StorageType == "SAMBA" {
-		valMap["username"] = types.StringValue(externalStorageResp.Spec.StorageUser.Username)
-		valMap["password"] = types.StringValue(externalStorageResp.Spec.StorageUser.Password)
-	} else {
-		valMap["username"] = types.StringNull()
-		valMap["password"] = types.StringNull()
-	}
-	if externalStorageResp.Spec.StorageShareDir != "" {
-		valMap["cm_storage_dir"] = types.StringValue(externalStorageResp.Spec.StorageShareDir)
-	} else {
-		valMap["cm_storage_dir"] = types.StringNull()
-	}
+	// CM generates cm_storage_dir when it is not set, keep the configured value to avoid a diff
+	valMap["cm_storage_dir"] = externalStorageModel.CMStorageDir
 
-	stateCfg.ExternalStorage, _ = types.ObjectValue(typeMap, valMap)
+	var objDiags diag.Diagnostics
+	resCfg.ExternalStorage, objDiags = types.ObjectValue(externalStorageAttrTypes, valMap)
+	diags.Append(objDiags...)
+	resCfg.ExternalStorageStatus = types.StringValue(externalStoreResp.Status.Setup)
+	resCfg.ExternalStorageFailureMessage = types.StringValue(externalStoreResp.Status.FailureMessage)
+	return diags
+}
 
-	bootstrap, err := r.client.GetCMBootstrap()
-	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError("Failed to read the bootstrap status", err.Error())
+// runBootstrap runs the Central Manager setup and sets the bootstrap attributes
+func (r *CMNextBootstrapResource) runBootstrap(ctx context.Context, resCfg *CMNextBootstrapResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var timeout int64
+	if resCfg.BootstrapTimeout.IsNull() {
+		timeout = 600
+	} else { // coverage-ignore
+		timeout = resCfg.BootstrapTimeout.ValueInt64()
 	}
-	bootstrapResp := &bigipnextsdk.BootstrapCMResp{}
-	err = json.Unmarshal([]byte(bootstrap), bootstrapResp)
+	res, err := r.client.BootstrapCM(timeout)
+
 	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError("Failed to unmarshal the bootstrap response", err.Error())
+		diags.AddError("Failed to bootstrap Central Manager:", err.Error())
+		return diags
 	}
 
-	id := extractIPFromUrl(r.client.Host)
+	bootStrapResp := &bigipnextsdk.BootstrapCMResp{}
+	err = json.Unmarshal([]byte(res), bootStrapResp)
+	if err != nil { // coverage-ignore
+		diags.AddError("Failed to unmarshal Central Manager bootstrap response:", err.Error())
+		return diags
+	}
 
-	stateCfg.Id = types.StringValue(fmt.Sprintf("setup-%s", id))
-	stateCfg.BootstrapStatus = types.StringValue(bootstrapResp.Status)
+	tflog.Info(ctx, "Central Manager bootstrap response: "+res)
+	resCfg.BootstrapStatus = types.StringValue(bootStrapResp.Status)
+	resCfg.BootstrapStep = types.StringValue(bootStrapResp.Step)
+	return diags
 }
 
-func (r *CMNextBootstrapResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	tflog.Info(ctx, "this resource does not support update operation")
-}
+// externalStorageToState refreshes the external storage attributes from CM, the password and the generated
+// cm_storage_dir are kept from the state as they are not reported back reliably
+func externalStorageToState(ctx context.Context, externalStorageResp *bigipnextsdk.CMExternalStorageResp, stateCfg *CMNextBootstrapResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	stateCfg.ExternalStorageStatus = types.StringValue(externalStorageResp.Status.Setup)
+	stateCfg.ExternalStorageFailureMessage = types.StringValue(externalStorageResp.Status.FailureMessage)
+	if externalStorageResp.Status.Setup != "" && externalStorageResp.Status.Setup != "SUCCESSFUL" {
+		tflog.Warn(ctx, fmt.Sprintf("External storage setup is %s: %s", externalStorageResp.Status.Setup, externalStorageResp.Status.FailureMessage))
+	}
+	if externalStorageResp.Spec.StorageType == "" {
+		tflog.Warn(ctx, "External storage is not configured on Central Manager")
+		stateCfg.ExternalStorage = types.ObjectNull(externalStorageAttrTypes)
+		return diags
+	}
 
-func (r *CMNextBootstrapResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	tflog.Info(ctx, "this resource does not support delete operation, it can oly be removed from the state")
-	resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(""))
+	var externalStorageModel ExternalStorage
+	diags.Append(stateCfg.ExternalStorage.As(ctx, &externalStorageModel, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() { // coverage-ignore
+		return diags
+	}
+	valMap := map[string]attr.Value{
+		"storage_type":    types.StringValue(externalStorageResp.Spec.StorageType),
+		"storage_address": types.StringValue(externalStorageResp.Spec.StorageAddress),
+		"storage_path":    types.StringValue(externalStorageResp.Spec.StorageSharePath),
+		"cm_storage_dir":  externalStorageModel.CMStorageDir,
+		"username":        types.StringNull(),
+		"password":        externalStorageModel.Password,
+	}
+	if externalStorageResp.Spec.StorageType == "SAMBA" && externalStorageResp.Spec.StorageUser != nil {
+		valMap["username"] = types.StringValue(externalStorageResp.Spec.StorageUser.Username)
+	}
+	var objDiags diag.Diagnostics
+	stateCfg.ExternalStorage, objDiags = types.ObjectValue(externalStorageAttrTypes, valMap)
+	diags.Append(objDiags...)
+	return diags
 }
 
 func getExternalStorageData(plan *ExternalStorage) *bigipnextsdk.CMExternalStorage {
@@ -1,12 +1,21 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -28,13 +37,14 @@ type CMDiscoveryNextResource struct {
 }
 
 type CMDiscoveryNextResourceModel struct {
-	Address            types.String `tfsdk:"address"`
-	Port               types.Int64  `tfsdk:"port"`
-	DeviceUser         types.String `tfsdk:"device_user"`
-	DevicePassword     types.String `tfsdk:"device_password"`
-	ManagementUser     types.String `tfsdk:"management_user"`
-	ManagementPassword types.String `tfsdk:"management_password"`
-	Id                 types.String `tfsdk:"id"`
+	Address              types.String `tfsdk:"address"`
+	Port                 types.Int64  `tfsdk:"port"`
+	DeviceUser           types.String `tfsdk:"device_user"`
+	DevicePassword       types.String `tfsdk:"device_password"`
+	ManagementUser       types.String `tfsdk:"management_user"`
+	ManagementPassword   types.String `tfsdk:"management_password"`
+	ResetDefaultPassword types.Bool   `tfsdk:"reset_default_password"`
+	Id                   types.String `tfsdk:"id"`
 }
 
 func (r *CMDiscoveryNextResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -71,6 +81,13 @@ func (r *CMDiscoveryNextResource) Schema(ctx context.Context, req resource.Schem
 				Sensitive:           true,
 				MarkdownDescription: "The password that the BIG-IP Next Central Manager uses after Instance Discovery for BIG-IP Next management",
 			},
+			"reset_default_password": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Set to `true` to change the first-boot password (`device_password`) of `device_user` to `management_password` on the instance before discovery." +
+					" The instance must still be in first-boot state, the reset is only performed when the resource is created. Default is `false`.",
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Unique Identifier for the resource",
@@ -98,9 +115,23 @@ func (r *CMDiscoveryNextResource) Create(ctx context.Context, req resource.Creat
 
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] Device Provider config:%+v\n", providerConfig))
 
+	if resCfg.ResetDefaultPassword.ValueBool() {
+		if err := resetInstanceDefaultPassword(r.client, providerConfig); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to reset default password, got error: %s", err))
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("[CREATE] Default password of user %s reset on instance %s", providerConfig.DeviceUser, providerConfig.Address))
+		providerConfig.DevicePassword = providerConfig.ManagementPassword
+	} else if providerConfig.DevicePassword == "admin" {
+		// DiscoverInstance changes the first-boot password "admin" on its own, the reset has to be asked for
+		resp.Diagnostics.AddAttributeError(path.Root("device_password"), "Default Password",
+			"device_password is the first-boot default password, set reset_default_password to true to change it to management_password before discovery")
+		return
+	}
+
 	respData, err := r.client.DiscoverInstance(providerConfig)
-	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Create Certificate, got error: %s", err))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Discover Instance, got error: %s", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] respData ID:%+v\n", string(respData)))
@@ -118,6 +149,11 @@ func (r *CMDiscoveryNextResource) Read(ctx context.Context, req resource.ReadReq
 	tflog.Info(ctx, fmt.Sprintf("Reading Instance Info : %+v", id))
 
 	deviceInfo, err := r.client.GetDeviceInfoByID(id)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Warn(ctx, fmt.Sprintf("Instance %s not found on CM, removing from state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read Instance Info, got error: %s", err))
 		return
@@ -125,6 +161,9 @@ func (r *CMDiscoveryNextResource) Read(ctx context.Context, req resource.ReadReq
 	tflog.Info(ctx, fmt.Sprintf("Instance Info : %+v", deviceInfo))
 
 	r.DiscoveryNextResourceModeltoState(ctx, deviceInfo, stateCfg)
+	if stateCfg.ResetDefaultPassword.IsNull() {
+		stateCfg.ResetDefaultPassword = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
 }
@@ -176,3 +215,47 @@ func getCMDiscoveryNextConfig(ctx context.Context, data *CMDiscoveryNextResource
 	discoverInstanceReq.ManagementPassword = data.ManagementPassword.ValueString()
 	return discoverInstanceReq
 }
+
+// resetInstanceDefaultPassword changes the first-boot password of the instance user to the management password.
+// The request is sent directly to the instance, re-using the scheme and transport of the CM session
+// /api/v1/me
+func resetInstanceDefaultPassword(client *bigipnextsdk.BigipNextCM, config *bigipnextsdk.DiscoverInstanceRequest) error {
+	scheme := "https"
+	if u, err := url.Parse(client.Host); err == nil && u.Scheme != "" {
+		scheme = u.Scheme
+	}
+	urlString := fmt.Sprintf("%s://%s/api/v1/me", scheme, net.JoinHostPort(config.Address, strconv.Itoa(config.Port)))
+	body, err := json.Marshal(map[string]string{
+		"currentPassword": config.DevicePassword,
+		"newPassword":     config.ManagementPassword,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("PUT", urlString, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(config.DeviceUser, config.DevicePassword)
+	httpClient := &http.Client{
+		Transport: client.Transport,
+		Timeout:   client.ConfigOptions.APICallTimeout,
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	respData, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("instance %s is not in first-boot state, default password of user %s was not accepted (Status:%+v, Body:%+v)", config.Address, config.DeviceUser, res.Status, string(respData))
+	}
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to reset password of user %s on instance %s (Status:%+v, Body:%+v)", config.DeviceUser, config.Address, res.Status, string(respData))
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+// f5osTenantImage is a tenant image or a tenant deployment file uploaded to the rSeries/VELOS platform
+type f5osTenantImage struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	InUse  bool   `json:"in-use"`
+}
+
+// f5osVlan is a VLAN of the rSeries/VELOS platform
+type f5osVlan struct {
+	Id   int64
+	Name string
+}
+
+// f5osNode is a node of the rSeries/VELOS platform which can run tenants, a blade of a VELOS partition
+// or the appliance for rSeries
+type f5osNode struct {
+	Name    string
+	Slot    int64
+	Enabled bool
+	State   string
+	// Tenants deployed on the node, with the CPU cores and the disk size in GB they use
+	Tenants      []string
+	CpuCoresUsed int64
+	DiskSizeUsed int64
+	// CpuCoresTotal and DiskSizeTotal are 0 when the platform does not report the capacity of its nodes
+	CpuCoresTotal int64
+	DiskSizeTotal int64
+}
+
+// isDeploymentFile tells if the image is the tenant deployment file of a BIG-IP Next image
+func (i f5osTenantImage) isDeploymentFile() bool {
+	return strings.HasSuffix(i.Name, ".yaml")
+}
+
+// getF5OSAPI reads the RESTCONF apiPath of the rSeries/VELOS platform of the provider into result, through the
+// F5OS provider API proxy of CM, the F5OS counterpart of the vSphere provider API proxy
+// https://clouddocs.f5.com/api/v1/spaces/default/providers/f5os/{provider-id}/api
+// The RESTCONF paths are the ones of the F5OS API reference https://clouddocs.f5.com/api/rseries-api/
+func getF5OSAPI(client *bigipnextsdk.BigipNextCM, providerID, apiPath string, result interface{}) error {
+	providerUrl := fmt.Sprintf("%s/f5os/%s/api?path=%s", uriCMProviders, providerID, apiPath)
+	respData, err := client.GetCMRequest(providerUrl)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(respData, result)
+}
+
+// getF5OSTenantImages lists the tenant images and tenant deployment files of the platform
+func getF5OSTenantImages(client *bigipnextsdk.BigipNextCM, providerID string) ([]f5osTenantImage, error) {
+	var images struct {
+		Images struct {
+			Image []f5osTenantImage `json:"image"`
+		} `json:"f5-tenant-images:images"`
+	}
+	if err := getF5OSAPI(client, providerID, "restconf/data/f5-tenant-images:images", &images); err != nil {
+		return nil, err
+	}
+	return images.Images.Image, nil
+}
+
+// getF5OSVlans lists the VLANs of the platform
+func getF5OSVlans(client *bigipnextsdk.BigipNextCM, providerID string) ([]f5osVlan, error) {
+	var vlans struct {
+		Vlans struct {
+			Vlan []struct {
+				VlanId int64 `json:"vlan-id"`
+				Config struct {
+					Name string `json:"name"`
+				} `json:"config"`
+			} `json:"vlan"`
+		} `json:"openconfig-vlan:vlans"`
+	}
+	if err := getF5OSAPI(client, providerID, "restconf/data/openconfig-vlan:vlans", &vlans); err != nil {
+		return nil, err
+	}
+	var result []f5osVlan
+	for _, vlan := range vlans.Vlans.Vlan {
+		result = append(result, f5osVlan{Id: vlan.VlanId, Name: vlan.Config.Name})
+	}
+	return result, nil
+}
+
+// getF5OSNodes lists the nodes of the platform with the resources used by the tenants deployed on them
+//
+// The nodes are read from restconf/data/f5-cluster:cluster/nodes and the tenants from restconf/data/f5-tenants:tenants,
+// a tenant uses vcpu-cores-per-node CPU cores and its storage size on each of its nodes. The capacity of a node is
+// read from its vcpu-cores-total and disk-size-total state, which not all F5OS versions report: CpuCoresTotal and
+// DiskSizeTotal are then 0 and the caller cannot check the capacity
+func getF5OSNodes(client *bigipnextsdk.BigipNextCM, providerID string) ([]f5osNode, error) {
+	var cluster struct {
+		Nodes struct {
+			Node []struct {
+				Name  string `json:"name"`
+				State struct {
+					Enabled       bool        `json:"enabled"`
+					SlotNumber    json.Number `json:"slot-number"`
+					RunningState  string      `json:"node-running-state"`
+					CpuCoresTotal json.Number `json:"vcpu-cores-total"`
+					DiskSizeTotal json.Number `json:"disk-size-total"`
+				} `json:"state"`
+			} `json:"node"`
+		} `json:"f5-cluster:nodes"`
+	}
+	if err := getF5OSAPI(client, providerID, "restconf/data/f5-cluster:cluster/nodes", &cluster); err != nil {
+		return nil, err
+	}
+	var tenants struct {
+		Tenants struct {
+			Tenant []struct {
+				Name   string `json:"name"`
+				Config struct {
+					Nodes            []json.Number `json:"nodes"`
+					VcpuCoresPerNode json.Number   `json:"vcpu-cores-per-node"`
+					Storage          struct {
+						Size json.Number `json:"size"`
+					} `json:"storage"`
+				} `json:"config"`
+			} `json:"tenant"`
+		} `json:"f5-tenants:tenants"`
+	}
+	if err := getF5OSAPI(client, providerID, "restconf/data/f5-tenants:tenants", &tenants); err != nil {
+		return nil, err
+	}
+	var nodes []f5osNode
+	for _, clusterNode := range cluster.Nodes.Node {
+		node := f5osNode{
+			Name:          clusterNode.Name,
+			Slot:          f5osNumber(clusterNode.State.SlotNumber),
+			Enabled:       clusterNode.State.Enabled,
+			State:         clusterNode.State.RunningState,
+			Tenants:       []string{},
+			CpuCoresTotal: f5osNumber(clusterNode.State.CpuCoresTotal),
+			DiskSizeTotal: f5osNumber(clusterNode.State.DiskSizeTotal),
+		}
+		for _, tenant := range tenants.Tenants.Tenant {
+			for _, slot := range tenant.Config.Nodes {
+				if f5osNumber(slot) != node.Slot {
+					continue
+				}
+				node.Tenants = append(node.Tenants, tenant.Name)
+				node.CpuCoresUsed += f5osNumber(tenant.Config.VcpuCoresPerNode)
+				node.DiskSizeUsed += f5osNumber(tenant.Config.Storage.Size)
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// f5osNumber returns the integer value of a RESTCONF number, which F5OS encodes as a JSON number or string,
+// or 0 if it is not a number
+func f5osNumber(number json.Number) int64 {
+	value, err := strconv.ParseInt(number.String(), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMF5OSImagesDataSource{}
+)
+
+func NewCMF5OSImagesDataSource() datasource.DataSource {
+	return &CMF5OSImagesDataSource{}
+}
+
+// CMF5OSImagesDataSource defines the data source implementation.
+type CMF5OSImagesDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMF5OSImagesDataSourceModel describes the data source data model.
+type CMF5OSImagesDataSourceModel struct {
+	ProviderName    types.String             `tfsdk:"provider_name"`
+	TenantImages    []CMF5OSTenantImageModel `tfsdk:"tenant_images"`
+	DeploymentFiles []CMF5OSTenantImageModel `tfsdk:"deployment_files"`
+	ID              types.String             `tfsdk:"id"`
+}
+
+type CMF5OSTenantImageModel struct {
+	Name   types.String `tfsdk:"name"`
+	Status types.String `tfsdk:"status"`
+	InUse  types.Bool   `tfsdk:"in_use"`
+}
+
+func (d *CMF5OSImagesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_f5os_images"
+}
+
+func (d *CMF5OSImagesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	imageAttributes := map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Name of the file on the platform.",
+		},
+		"status": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Status of the file on the platform, such as `replicated` or `verified`.",
+		},
+		"in_use": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Whether tenants of the platform use the file.",
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the tenant images and tenant deployment files uploaded to the rSeries or VELOS platform of an F5OS provider of BIG-IP Next Central Manager, to check or pick the `tenant_image_name` and `tenant_deployment_file` of `bigipnext_cm_deploy_f5os`.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the `RSERIES` or `VELOS` provider on BIG-IP Next Central Manager.",
+			},
+			"tenant_images": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of tenant images of the platform.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: imageAttributes,
+				},
+			},
+			"deployment_files": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of tenant deployment files of the platform, the `.yaml` files of the BIG-IP Next images.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: imageAttributes,
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the provider.",
+			},
+		},
+	}
+}
+
+func (d *CMF5OSImagesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMF5OSImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMF5OSImagesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	provider, err := findF5OSProvider(d.client, data.ProviderName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Lookup Error", err.Error())
+		return
+	}
+	images, err := getF5OSTenantImages(d.client, provider.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read F5OS tenant images, got error: %s", err))
+		return
+	}
+	data.TenantImages = []CMF5OSTenantImageModel{}
+	data.DeploymentFiles = []CMF5OSTenantImageModel{}
+	for _, image := range images {
+		imageModel := CMF5OSTenantImageModel{
+			Name:   types.StringValue(image.Name),
+			Status: types.StringValue(image.Status),
+			InUse:  types.BoolValue(image.InUse),
+		}
+		if image.isDeploymentFile() {
+			data.DeploymentFiles = append(data.DeploymentFiles, imageModel)
+		} else {
+			data.TenantImages = append(data.TenantImages, imageModel)
+		}
+	}
+	data.ID = types.StringValue(provider.Id)
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findF5OSProvider returns the RSERIES or VELOS provider of CM named name
+func findF5OSProvider(client *bigipnextsdk.BigipNextCM, name string) (*cmProviderSummary, error) {
+	providers, err := listCMProviders(client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read providers, got error: %s", err)
+	}
+	return cmProviderByName(providers, name, "RSERIES", "VELOS")
+}
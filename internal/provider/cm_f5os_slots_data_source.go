@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMF5OSSlotsDataSource{}
+)
+
+func NewCMF5OSSlotsDataSource() datasource.DataSource {
+	return &CMF5OSSlotsDataSource{}
+}
+
+// CMF5OSSlotsDataSource defines the data source implementation.
+type CMF5OSSlotsDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMF5OSSlotsDataSourceModel describes the data source data model.
+type CMF5OSSlotsDataSourceModel struct {
+	ProviderName types.String      `tfsdk:"provider_name"`
+	Slots        []CMF5OSSlotModel `tfsdk:"slots"`
+	FreeSlots    []types.Int64     `tfsdk:"free_slots"`
+	ID           types.String      `tfsdk:"id"`
+}
+
+type CMF5OSSlotModel struct {
+	Slot          types.Int64    `tfsdk:"slot"`
+	Name          types.String   `tfsdk:"name"`
+	Enabled       types.Bool     `tfsdk:"enabled"`
+	State         types.String   `tfsdk:"state"`
+	Tenants       []types.String `tfsdk:"tenants"`
+	CpuCoresUsed  types.Int64    `tfsdk:"cpu_cores_used"`
+	CpuCoresTotal types.Int64    `tfsdk:"cpu_cores_total"`
+	CpuCoresFree  types.Int64    `tfsdk:"cpu_cores_free"`
+	DiskSizeUsed  types.Int64    `tfsdk:"disk_size_used"`
+	DiskSizeTotal types.Int64    `tfsdk:"disk_size_total"`
+	DiskSizeFree  types.Int64    `tfsdk:"disk_size_free"`
+}
+
+func (d *CMF5OSSlotsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_f5os_slots"
+}
+
+func (d *CMF5OSSlotsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the slots of the rSeries or VELOS platform of an F5OS provider of BIG-IP Next Central Manager with their tenants and CPU/disk capacity, to check or pick the `slot_ids`, `cpu_cores` and `disk_size` of `bigipnext_cm_deploy_f5os`.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the `RSERIES` or `VELOS` provider on BIG-IP Next Central Manager.",
+			},
+			"slots": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of slots of the platform, the blades of a VELOS partition or the single slot `1` of an rSeries appliance.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"slot": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Slot number, as used in `slot_ids`.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the node in the slot.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether tenants can be deployed in the slot.",
+						},
+						"state": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Running state of the node in the slot, such as `running`.",
+						},
+						"tenants": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Names of the tenants deployed in the slot.",
+						},
+						"cpu_cores_used": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of CPU cores used by the tenants of the slot.",
+						},
+						"cpu_cores_total": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of CPU cores of the slot available to tenants, null if the platform does not report it.",
+						},
+						"cpu_cores_free": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of CPU cores of the slot not used by tenants, null if the platform does not report `cpu_cores_total`.",
+						},
+						"disk_size_used": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Disk size used by the tenants of the slot, in GB.",
+						},
+						"disk_size_total": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Disk size of the slot available to tenants in GB, null if the platform does not report it.",
+						},
+						"disk_size_free": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Disk size of the slot not used by tenants in GB, null if the platform does not report `disk_size_total`.",
+						},
+					},
+				},
+			},
+			"free_slots": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Numbers of the enabled slots without any tenant.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the provider.",
+			},
+		},
+	}
+}
+
+func (d *CMF5OSSlotsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMF5OSSlotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMF5OSSlotsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	provider, err := findF5OSProvider(d.client, data.ProviderName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Lookup Error", err.Error())
+		return
+	}
+	nodes, err := getF5OSNodes(d.client, provider.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read F5OS slots, got error: %s", err))
+		return
+	}
+	data.Slots = []CMF5OSSlotModel{}
+	data.FreeSlots = []types.Int64{}
+	for _, node := range nodes {
+		slotModel := CMF5OSSlotModel{
+			Slot:          types.Int64Value(node.Slot),
+			Name:          types.StringValue(node.Name),
+			Enabled:       types.BoolValue(node.Enabled),
+			State:         types.StringValue(node.State),
+			Tenants:       []types.String{},
+			CpuCoresUsed:  types.Int64Value(node.CpuCoresUsed),
+			CpuCoresTotal: types.Int64Null(),
+			CpuCoresFree:  types.Int64Null(),
+			DiskSizeUsed:  types.Int64Value(node.DiskSizeUsed),
+			DiskSizeTotal: types.Int64Null(),
+			DiskSizeFree:  types.Int64Null(),
+		}
+		for _, tenant := range node.Tenants {
+			slotModel.Tenants = append(slotModel.Tenants, types.StringValue(tenant))
+		}
+		if node.CpuCoresTotal > 0 {
+			slotModel.CpuCoresTotal = types.Int64Value(node.CpuCoresTotal)
+			slotModel.CpuCoresFree = types.Int64Value(node.CpuCoresTotal - node.CpuCoresUsed)
+		}
+		if node.DiskSizeTotal > 0 {
+			slotModel.DiskSizeTotal = types.Int64Value(node.DiskSizeTotal)
+			slotModel.DiskSizeFree = types.Int64Value(node.DiskSizeTotal - node.DiskSizeUsed)
+		}
+		data.Slots = append(data.Slots, slotModel)
+		if node.Enabled && len(node.Tenants) == 0 {
+			data.FreeSlots = append(data.FreeSlots, types.Int64Value(node.Slot))
+		}
+	}
+	data.ID = types.StringValue(provider.Id)
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMF5OSVlansDataSource{}
+)
+
+func NewCMF5OSVlansDataSource() datasource.DataSource {
+	return &CMF5OSVlansDataSource{}
+}
+
+// CMF5OSVlansDataSource defines the data source implementation.
+type CMF5OSVlansDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMF5OSVlansDataSourceModel describes the data source data model.
+type CMF5OSVlansDataSourceModel struct {
+	ProviderName types.String      `tfsdk:"provider_name"`
+	Vlans        []CMF5OSVlanModel `tfsdk:"vlans"`
+	ID           types.String      `tfsdk:"id"`
+}
+
+type CMF5OSVlanModel struct {
+	Id   types.Int64  `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (d *CMF5OSVlansDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_f5os_vlans"
+}
+
+func (d *CMF5OSVlansDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the VLANs of the rSeries or VELOS platform of an F5OS provider of BIG-IP Next Central Manager, to check or pick the `vlan_ids` of `bigipnext_cm_deploy_f5os`.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the `RSERIES` or `VELOS` provider on BIG-IP Next Central Manager.",
+			},
+			"vlans": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of VLANs of the platform.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "VLAN ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the VLAN.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the provider.",
+			},
+		},
+	}
+}
+
+func (d *CMF5OSVlansDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMF5OSVlansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMF5OSVlansDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	provider, err := findF5OSProvider(d.client, data.ProviderName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Lookup Error", err.Error())
+		return
+	}
+	vlans, err := getF5OSVlans(d.client, provider.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read F5OS VLANs, got error: %s", err))
+		return
+	}
+	data.Vlans = []CMF5OSVlanModel{}
+	for _, vlan := range vlans {
+		data.Vlans = append(data.Vlans, CMF5OSVlanModel{
+			Id:   types.Int64Value(vlan.Id),
+			Name: types.StringValue(vlan.Name),
+		})
+	}
+	data.ID = types.StringValue(provider.Id)
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,345 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource = &CMFileResource{}
+)
+
+func NewCMFileResource() resource.Resource {
+	return &CMFileResource{}
+}
+
+type CMFileResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type CMFileResourceModel struct {
+	FilePath   types.String `tfsdk:"file_path"`
+	InstanceIP types.String `tfsdk:"instance_ip"`
+	FileName   types.String `tfsdk:"file_name"`
+	Sha256     types.String `tfsdk:"sha256"`
+	Size       types.Int64  `tfsdk:"size"`
+	Id         types.String `tfsdk:"id"`
+}
+
+func (r *CMFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_file"
+}
+
+func (r *CMFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to upload files, such as BIG-IP Next images and signature files, to Central Manager or through Central Manager to a BIG-IP Next instance.\n\n" +
+			"The SHA-256 checksum of the local file is compared with the checksum of the uploaded file, the file is uploaded again when either of them changes.",
+		Attributes: map[string]schema.Attribute{
+			"file_path": schema.StringAttribute{
+				MarkdownDescription: "Local path of the file that is to be uploaded, the file is uploaded with the base name of the path.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"instance_ip": schema.StringAttribute{
+				MarkdownDescription: "Management IP of the BIG-IP Next instance the file is uploaded to through the Central Manager proxy. When not set the file is uploaded to Central Manager.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the uploaded file.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 checksum of the file.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					sha256FromFilePath(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "Size of the uploaded file in bytes.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the uploaded file.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CMFileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *CMFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *CMFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	filePath := resCfg.FilePath.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] Upload file:%+v", filePath))
+
+	checksum, err := fileSha256(filePath)
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("File Error", fmt.Sprintf("Failed to read file %s, got error: %s", filePath, err))
+		return
+	}
+
+	var fileInfo *cmFileInfo
+	if resCfg.InstanceIP.IsNull() {
+		fileInfo, _, err = uploadFileToCM(ctx, r.client, filePath, checksum)
+	} else {
+		var instanceId string
+		instanceId, err = r.client.GetNextInstanceID(resCfg.InstanceIP.ValueString())
+		if err == nil {
+			fileInfo, err = uploadFileToInstance(ctx, r.client, instanceId, filePath, checksum)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to upload file %s, got error: %s", filePath, err))
+		return
+	}
+	setCMFileState(resCfg, fileInfo)
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	fileName := stateCfg.FileName.ValueString()
+	var fileInfo *cmFileInfo
+	var err error
+	if stateCfg.InstanceIP.IsNull() {
+		fileInfo, err = getCMFileInfo(r.client, fileName)
+	} else {
+		var instanceId string
+		instanceId, err = r.client.GetNextInstanceID(stateCfg.InstanceIP.ValueString())
+		if err != nil && strings.Contains(err.Error(), "not found") {
+			tflog.Warn(ctx, fmt.Sprintf("NEXT instance %s not found, removing file %s from state", stateCfg.InstanceIP.ValueString(), fileName))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if err == nil {
+			fileInfo, err = getInstanceFileInfo(r.client, instanceId, fileName)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to read file %s, got error: %s", fileName, err))
+		return
+	}
+	if fileInfo == nil {
+		tflog.Warn(ctx, fmt.Sprintf("File %s not found, removing it from state", fileName))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	setCMFileState(stateCfg, fileInfo)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *CMFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg *CMFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	// all configurable attributes require replacement
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *CMFileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	fileName := stateCfg.FileName.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[DELETE] Delete file:%+v", fileName))
+	var err error
+	if stateCfg.InstanceIP.IsNull() {
+		err = deleteCMFile(r.client, fileName)
+	} else {
+		var instanceId string
+		instanceId, err = r.client.GetNextInstanceID(stateCfg.InstanceIP.ValueString())
+		if err == nil {
+			err = deleteInstanceFile(r.client, instanceId, stateCfg.Id.ValueString())
+		}
+	}
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to delete file %s, got error: %s", fileName, err))
+		return
+	}
+	stateCfg.Id = types.StringValue("")
+}
+
+func setCMFileState(data *CMFileResourceModel, fileInfo *cmFileInfo) {
+	data.Id = types.StringValue(fileInfo.Id)
+	data.FileName = types.StringValue(fileInfo.FileName)
+	data.Sha256 = types.StringValue(strings.ToLower(fileInfo.Hash))
+	data.Size = types.Int64Value(fileInfo.FileSize)
+}
+
+// uploadFileToCM uploads the file unless CM already has a file with the same name and checksum,
+// and verifies the checksum CM computed for the uploaded file. It tells whether the file was uploaded
+func uploadFileToCM(ctx context.Context, client *bigipnextsdk.BigipNextCM, filePath, checksum string) (*cmFileInfo, bool, error) {
+	fileName := filepath.Base(filePath)
+	fileInfo, err := getCMFileInfo(client, fileName)
+	if err != nil {
+		return nil, false, err
+	}
+	if fileInfo != nil && strings.EqualFold(fileInfo.Hash, checksum) {
+		tflog.Info(ctx, fmt.Sprintf("File %s already present on CM with matching checksum, skipping upload", fileName))
+		return fileInfo, false, nil
+	}
+	if fileInfo != nil {
+		tflog.Info(ctx, fmt.Sprintf("File %s present on CM with different checksum, replacing it", fileName))
+		if err = deleteCMFile(client, fileName); err != nil {
+			return nil, false, err
+		}
+	}
+	if _, err = uploadCMFile(client, filePath); err != nil {
+		return nil, false, err
+	}
+	fileInfo, err = getCMFileInfo(client, fileName)
+	if err != nil {
+		return nil, false, err
+	}
+	if fileInfo == nil {
+		return nil, false, fmt.Errorf("file %s not found on CM after upload", fileName)
+	}
+	if !strings.EqualFold(fileInfo.Hash, checksum) {
+		_ = deleteCMFile(client, fileName)
+		return nil, false, fmt.Errorf("checksum of uploaded file %s is %s, expected %s", fileName, fileInfo.Hash, checksum)
+	}
+	return fileInfo, true, nil
+}
+
+// uploadFileToInstance uploads the file to the NEXT instance through the CM proxy unless the instance
+// already has a file with the same name and checksum, and verifies the checksum of the uploaded file
+func uploadFileToInstance(ctx context.Context, client *bigipnextsdk.BigipNextCM, instanceId, filePath, checksum string) (*cmFileInfo, error) {
+	fileName := filepath.Base(filePath)
+	fileInfo, err := getInstanceFileInfo(client, instanceId, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if fileInfo != nil && strings.EqualFold(fileInfo.Hash, checksum) {
+		tflog.Info(ctx, fmt.Sprintf("File %s already present on instance %s with matching checksum, skipping upload", fileName, instanceId))
+		return fileInfo, nil
+	}
+	if fileInfo != nil {
+		tflog.Info(ctx, fmt.Sprintf("File %s present on instance %s with different checksum, replacing it", fileName, instanceId))
+		if err = deleteInstanceFile(client, instanceId, fileInfo.Id); err != nil {
+			return nil, err
+		}
+	}
+	if _, err = uploadInstanceFile(client, instanceId, filePath); err != nil {
+		return nil, err
+	}
+	fileInfo, err = getInstanceFileInfo(client, instanceId, fileName)
+	if err != nil {
+		return nil, err
+	}
+	if fileInfo == nil {
+		return nil, fmt.Errorf("file %s not found on instance %s after upload", fileName, instanceId)
+	}
+	if !strings.EqualFold(fileInfo.Hash, checksum) {
+		_ = deleteInstanceFile(client, instanceId, fileInfo.Id)
+		return nil, fmt.Errorf("checksum of uploaded file %s is %s, expected %s", fileName, fileInfo.Hash, checksum)
+	}
+	return fileInfo, nil
+}
+
+// getInstanceFileInfo returns the details of the file with given name on the NEXT instance, nil if it is not present
+func getInstanceFileInfo(client *bigipnextsdk.BigipNextCM, instanceId, fileName string) (*cmFileInfo, error) {
+	files, err := getNextInstanceFiles(client, instanceId)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files.Embedded.Files {
+		if file.Name == fileName {
+			return &cmFileInfo{
+				FileName: file.Name,
+				FileSize: file.Size,
+				Hash:     file.Hash,
+				Id:       file.Id,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// fileSha256 returns the hex encoded SHA-256 checksum of the file content
+func fileSha256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func sha256FromFilePath() planmodifier.String {
+	return sha256FromFilePathPlanModifier{}
+}
+
+type sha256FromFilePathPlanModifier struct{}
+
+func (m sha256FromFilePathPlanModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m sha256FromFilePathPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return "Sets the SHA-256 checksum of the local file at file_path"
+}
+
+func (m sha256FromFilePathPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var filePath types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("file_path"), &filePath)...)
+	if resp.Diagnostics.HasError() || filePath.IsUnknown() || filePath.IsNull() {
+		return
+	}
+	checksum, err := fileSha256(filePath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("file_path"),
+			"File Error",
+			fmt.Sprintf("Failed to read file %s, got error: %s", filePath.ValueString(), err),
+		)
+		return
+	}
+	resp.PlanValue = types.StringValue(checksum)
+}
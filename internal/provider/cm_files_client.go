@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+const (
+	uriCMFiles           = "/system/v1/files"
+	uriCMFileUpload      = "/v1/spaces/default/files"
+	uriCMProxy           = "/device/v1/proxy"
+	uriCMProxyFileUpload = "/device/v1/proxy-file-upload"
+	// cmFileTransferTimeout bounds the upload and download of files, such as BIG-IP Next images and backups
+	cmFileTransferTimeout = 30 * time.Minute
+	// cmTokenRefreshInterval is the interval the CM token is refreshed at while a file is transferred
+	cmTokenRefreshInterval = 2 * time.Minute
+)
+
+// cmFileInfo is a file uploaded to CM, or to a NEXT instance through the CM proxy
+type cmFileInfo struct {
+	Description string `json:"description,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	FileSize    int64  `json:"file_size,omitempty"`
+	Hash        string `json:"hash,omitempty"`
+	Id          string `json:"id,omitempty"`
+	Updated     string `json:"updated,omitempty"`
+}
+
+type cmFiles struct {
+	Embedded struct {
+		Files []cmFileInfo `json:"files,omitempty"`
+	} `json:"_embedded,omitempty"`
+}
+
+// getCMFiles returns the files uploaded to CM
+// /api/system/v1/files
+func getCMFiles(client *bigipnextsdk.BigipNextCM) (*cmFiles, error) {
+	respData, err := client.GetCMRequest(uriCMFiles)
+	if err != nil {
+		return nil, err
+	}
+	var files cmFiles
+	if err = json.Unmarshal(respData, &files); err != nil {
+		return nil, err
+	}
+	return &files, nil
+}
+
+// getCMFileInfo returns the details of the file uploaded to CM with given name, nil if it is not present
+func getCMFileInfo(client *bigipnextsdk.BigipNextCM, fileName string) (*cmFileInfo, error) {
+	respData, err := client.GetFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	var files cmFiles
+	if err = json.Unmarshal(respData, &files); err != nil {
+		return nil, err
+	}
+	for _, file := range files.Embedded.Files {
+		if file.FileName == fileName {
+			return &file, nil
+		}
+	}
+	return nil, nil
+}
+
+// deleteCMFile deletes the file uploaded to CM with given name, an error is returned when there is no such file
+// /api/system/v1/files/{id}
+func deleteCMFile(client *bigipnextsdk.BigipNextCM, fileName string) error {
+	fileInfo, err := getCMFileInfo(client, fileName)
+	if err != nil {
+		return err
+	}
+	if fileInfo == nil || fileInfo.Id == "" {
+		return fmt.Errorf("the requested file:%s, was not found", fileName)
+	}
+	_, err = client.DeleteCMRequest(fmt.Sprintf("%s/%s", uriCMFiles, fileInfo.Id))
+	return err
+}
+
+// uploadCMFile uploads the local file to CM with the base name of its path
+// /api/v1/spaces/default/files
+func uploadCMFile(client *bigipnextsdk.BigipNextCM, filePath string) ([]byte, error) {
+	return postCMFile(client, uriCMFileUpload, filePath)
+}
+
+// getNextInstanceFiles returns the files uploaded to the NEXT instance
+// /api/device/v1/proxy/{id}?path=/files
+func getNextInstanceFiles(client *bigipnextsdk.BigipNextCM, instanceId string) (*bigipnextsdk.FilesOnNextInstance, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s/%s?path=%s", uriCMProxy, instanceId, url.QueryEscape("/files")))
+	if err != nil {
+		return nil, err
+	}
+	var files bigipnextsdk.FilesOnNextInstance
+	if err = json.Unmarshal(respData, &files); err != nil {
+		return nil, err
+	}
+	return &files, nil
+}
+
+// uploadInstanceFile uploads the local file to the NEXT instance through the CM proxy
+// /api/device/v1/proxy-file-upload/{id}
+func uploadInstanceFile(client *bigipnextsdk.BigipNextCM, instanceId, filePath string) ([]byte, error) {
+	return postCMFile(client, fmt.Sprintf("%s/%s", uriCMProxyFileUpload, instanceId), filePath)
+}
+
+// deleteInstanceFile deletes the file with given ID from the NEXT instance through the CM proxy
+// /api/device/v1/proxy/{id}?path=/files/{fileId}
+func deleteInstanceFile(client *bigipnextsdk.BigipNextCM, instanceId, fileId string) error {
+	_, err := client.DeleteCMRequest(fmt.Sprintf("%s/%s?path=%s", uriCMProxy, instanceId, url.QueryEscape("/files/"+fileId)))
+	return err
+}
+
+// downloadCMFile streams the CM file with given ID, such as a CM backup file, to w
+// /api/system/v1/files/{id}/download
+func downloadCMFile(client *bigipnextsdk.BigipNextCM, fileId string, w io.Writer) (int64, error) {
+	return downloadCMPath(client, fmt.Sprintf("%s/%s/download", uriCMFiles, fileId), w)
+}
+
+// postCMFile uploads the local file with the base name of its path as multi-part form data to the CM API path
+func postCMFile(client *bigipnextsdk.BigipNextCM, path, filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	fileName := filepath.Base(filePath)
+	fields := map[string]string{
+		"description": "CM upgrade",
+		"file_name":   fileName,
+	}
+	return postCMMultipart(client, path, fields, fileName, file)
+}
+
+// postCMMultipart posts content as the "content" part named fileName, with the form fields, to the CM API path.
+// The CM token is refreshed while the request runs, large files take longer to upload than the token is valid
+func postCMMultipart(client *bigipnextsdk.BigipNextCM, path string, fields map[string]string, fileName string, content io.Reader) ([]byte, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="content"; filename="%s"`, fileName))
+	h.Set("Content-Type", "application/octet-stream")
+	part, err := writer.CreatePart(h)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.Copy(part, content); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err = writer.WriteField(name, fields[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api%s", client.Host, path), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	var respData []byte
+	err = withCMTokenRefresh(client, func() error {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.Token))
+		httpClient := &http.Client{
+			Transport: client.Transport,
+			Timeout:   cmFileTransferTimeout,
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		respData, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf(`{"code":%d,"error":%s`, resp.StatusCode, respData)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return respData, nil
+}
+
+// downloadCMPath streams the body of a GET request of the CM API path to w without buffering it in memory
+func downloadCMPath(client *bigipnextsdk.BigipNextCM, path string, w io.Writer) (int64, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api%s", client.Host, path), nil)
+	if err != nil {
+		return 0, err
+	}
+	var written int64
+	err = withCMTokenRefresh(client, func() error {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.Token))
+		httpClient := &http.Client{
+			Transport: client.Transport,
+			Timeout:   cmFileTransferTimeout,
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			byteData, _ := io.ReadAll(resp.Body)
+			return fmt.Errorf(`{"code":%d,"error":%s`, resp.StatusCode, byteData)
+		}
+		written, err = io.Copy(w, resp.Body)
+		return err
+	})
+	return written, err
+}
+
+// withCMTokenRefresh runs the long running request while the CM token is refreshed in the background,
+// the refresh stops as soon as the request returns
+func withCMTokenRefresh(client *bigipnextsdk.BigipNextCM, request func() error) error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(cmTokenRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// a failed refresh is retried on the next tick, the request fails on its own once the token expires
+				_ = client.CMTokenRefreshNew()
+			}
+		}
+	}()
+	err := request()
+	close(done)
+	<-stopped
+	return err
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMFilesDataSource{}
+)
+
+func NewCMFilesDataSource() datasource.DataSource {
+	return &CMFilesDataSource{}
+}
+
+// CMFilesDataSource defines the data source implementation.
+type CMFilesDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMFilesDataSourceModel describes the data source data model.
+type CMFilesDataSourceModel struct {
+	ID         types.String        `tfsdk:"id"`
+	InstanceIP types.String        `tfsdk:"instance_ip"`
+	Files      []CMFileSourceModel `tfsdk:"files"`
+}
+
+type CMFileSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	FileName    types.String `tfsdk:"file_name"`
+	Hash        types.String `tfsdk:"hash"`
+	Size        types.Int64  `tfsdk:"size"`
+	Description types.String `tfsdk:"description"`
+	Updated     types.String `tfsdk:"updated"`
+}
+
+func (d *CMFilesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_files"
+}
+
+func (d *CMFilesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get information about the files uploaded to Central Manager or to a BIG-IP Next instance.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this data source: `cm` for Central Manager files, otherwise ID of the BIG-IP Next instance.",
+			},
+			"instance_ip": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Management IP of the BIG-IP Next instance to list the files of. When not set the files on Central Manager are listed.",
+			},
+			"files": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of files.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the file.",
+						},
+						"file_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the file.",
+						},
+						"hash": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "SHA-256 checksum of the file.",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Size of the file in bytes.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Description of the file, only reported for Central Manager files.",
+						},
+						"updated": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Time the file was last updated, only reported for Central Manager files.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CMFilesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMFilesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMFilesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	data.Files = []CMFileSourceModel{}
+	if data.InstanceIP.IsNull() {
+		files, err := getCMFiles(d.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read CM files, got error: %s", err))
+			return
+		}
+		for _, file := range files.Embedded.Files {
+			data.Files = append(data.Files, CMFileSourceModel{
+				Id:          types.StringValue(file.Id),
+				FileName:    types.StringValue(file.FileName),
+				Hash:        types.StringValue(file.Hash),
+				Size:        types.Int64Value(file.FileSize),
+				Description: types.StringValue(file.Description),
+				Updated:     types.StringValue(file.Updated),
+			})
+		}
+		data.ID = types.StringValue("cm")
+	} else {
+		instanceId, err := d.client.GetNextInstanceID(data.InstanceIP.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find NEXT instance %s, got error: %s", data.InstanceIP.ValueString(), err))
+			return
+		}
+		files, err := getNextInstanceFiles(d.client, instanceId)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read files on NEXT instance %s, got error: %s", data.InstanceIP.ValueString(), err))
+			return
+		}
+		for _, file := range files.Embedded.Files {
+			data.Files = append(data.Files, CMFileSourceModel{
+				Id:          types.StringValue(file.Id),
+				FileName:    types.StringValue(file.Name),
+				Hash:        types.StringValue(file.Hash),
+				Size:        types.Int64Value(file.Size),
+				Description: types.StringValue(""),
+				Updated:     types.StringValue(""),
+			})
+		}
+		data.ID = types.StringValue(instanceId)
+	}
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,484 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource                   = &CMFleetBackupResource{}
+	_ resource.ResourceWithValidateConfig = &CMFleetBackupResource{}
+)
+
+func NewCMFleetBackupResource() resource.Resource {
+	return &CMFleetBackupResource{}
+}
+
+type CMFleetBackupResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type CMFleetBackupResourceModel struct {
+	BackupPassword types.String `tfsdk:"backup_password"`
+	FileNamePrefix types.String `tfsdk:"file_name_prefix"`
+	Filter         types.Object `tfsdk:"filter"`
+	Parallelism    types.Int64  `tfsdk:"parallelism"`
+	Timeout        types.Int64  `tfsdk:"timeout"`
+	Backups        types.List   `tfsdk:"backups"`
+	FailedCount    types.Int64  `tfsdk:"failed_count"`
+	Id             types.String `tfsdk:"id"`
+}
+
+type CMFleetBackupFilterModel struct {
+	Versions        types.List   `tfsdk:"versions"`
+	HostnamePattern types.String `tfsdk:"hostname_pattern"`
+}
+
+type CMFleetBackupInstanceModel struct {
+	InstanceId    types.String `tfsdk:"instance_id"`
+	Hostname      types.String `tfsdk:"hostname"`
+	Address       types.String `tfsdk:"address"`
+	Version       types.String `tfsdk:"version"`
+	FileName      types.String `tfsdk:"file_name"`
+	BackupDate    types.String `tfsdk:"backup_date"`
+	Status        types.String `tfsdk:"status"`
+	FailureReason types.String `tfsdk:"failure_reason"`
+}
+
+var cmFleetBackupInstanceAttrTypes = map[string]attr.Type{
+	"instance_id":    types.StringType,
+	"hostname":       types.StringType,
+	"address":        types.StringType,
+	"version":        types.StringType,
+	"file_name":      types.StringType,
+	"backup_date":    types.StringType,
+	"status":         types.StringType,
+	"failure_reason": types.StringType,
+}
+
+// cmFleetInstance is an instance of the CM inventory selected for the fleet backup
+type cmFleetInstance struct {
+	Id       string
+	Hostname string
+	Address  string
+	Version  string
+}
+
+func (r *CMFleetBackupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_fleet_backup"
+}
+
+func (r *CMFleetBackupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to back up all BIG-IP Next instances managed by BIG-IP Next Central Manager, or the instances matching `filter`.\n\n" +
+			"Instances are backed up concurrently, up to `parallelism` at a time. A failed backup of one instance does not stop the others, " +
+			"failures are reported as a warning and recorded in `backups`. Use `terraform apply -replace` to take a new set of backups.\n\n" +
+			"When doing `terraform destroy` the backup files are deleted from Central Manager.",
+		Attributes: map[string]schema.Attribute{
+			"backup_password": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "User password for the backup files.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_name_prefix": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Prefix of the backup file names, the backup of each instance is named `<file_name_prefix>-<hostname>.tar.gz`. The default value is `fleet-backup`.",
+				Default:             stringdefault.StaticString("fleet-backup"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"filter": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Selects the instances to back up, when not set all instances in the inventory are backed up. When both attributes are set an instance must match both.",
+				Attributes: map[string]schema.Attribute{
+					"versions": schema.ListAttribute{
+						Optional:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "Back up the instances running one of these BIG-IP Next versions, for example `20.3.0-2.538.2`.",
+					},
+					"hostname_pattern": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Back up the instances with a hostname matching this regular expression, for example `^prod-`.",
+					},
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Maximum number of instances backed up at the same time. The default value is `5`.",
+				Default:             int64default.StaticInt64(5),
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The number of seconds to wait for the backup of each instance to complete.",
+				Default:             int64default.StaticInt64(360),
+			},
+			"backups": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Result of the backup of each selected instance.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "UUID of the instance.",
+						},
+						"hostname": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Hostname of the instance.",
+						},
+						"address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Management address of the instance.",
+						},
+						"version": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "BIG-IP Next version of the instance.",
+						},
+						"file_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the backup file.",
+						},
+						"backup_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The timestamp when the backup file was created. In ISO 8601 format.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Status of the backup, `completed`, `failed`, or `deleted` when the backup file was removed from Central Manager.",
+						},
+						"failure_reason": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Reason the backup failed.",
+						},
+					},
+				},
+			},
+			"failed_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of instances whose backup failed.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique Identifier for the resource.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CMFleetBackupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var parallelism types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("parallelism"), &parallelism)...)
+	if !parallelism.IsNull() && !parallelism.IsUnknown() && parallelism.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("parallelism"), "Invalid Parallelism", "parallelism must be at least 1.")
+	}
+	var hostnamePattern types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("filter").AtName("hostname_pattern"), &hostnamePattern)...)
+	if hostnamePattern.IsNull() || hostnamePattern.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(hostnamePattern.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("filter").AtName("hostname_pattern"), "Invalid Hostname Pattern",
+			fmt.Sprintf("hostname_pattern is not a valid regular expression: %s", err))
+	}
+}
+
+func (r *CMFleetBackupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *CMFleetBackupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *CMFleetBackupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] CM Fleet Backup:%+v", resCfg.FileNamePrefix.ValueString()))
+
+	resp.Diagnostics.Append(r.backupFleet(ctx, resCfg)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resCfg.Id = types.StringValue(resCfg.FileNamePrefix.ValueString())
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMFleetBackupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMFleetBackupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	var backups []CMFleetBackupInstanceModel
+	resp.Diagnostics.Append(stateCfg.Backups.ElementsAs(ctx, &backups, false)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Reading Fleet Backup : %s", stateCfg.Id.ValueString()))
+
+	completed := 0
+	for i := range backups {
+		if backups[i].Status.ValueString() != "completed" {
+			continue
+		}
+		fileName := backups[i].FileName.ValueString()
+		if _, err := r.client.GetBackupFile(&fileName); err != nil {
+			if !strings.Contains(err.Error(), `"code":404`) {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read backup file %s, got error: %s", fileName, err))
+				return
+			}
+			tflog.Warn(ctx, fmt.Sprintf("Backup file %s of instance %s not found", fileName, backups[i].Hostname.ValueString()))
+			backups[i].Status = types.StringValue("deleted")
+			continue
+		}
+		completed++
+	}
+	if completed == 0 {
+		tflog.Warn(ctx, "No backup file of the fleet backup found, removing it from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	backupList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: cmFleetBackupInstanceAttrTypes}, backups)
+	resp.Diagnostics.Append(diags...)
+	stateCfg.Backups = backupList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *CMFleetBackupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg, stateCfg *CMFleetBackupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	// parallelism and timeout only apply to the next backup run
+	if resCfg.Filter.Equal(stateCfg.Filter) {
+		resCfg.Backups = stateCfg.Backups
+		resCfg.FailedCount = stateCfg.FailedCount
+		resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[UPDATE] CM Fleet Backup filter changed:%+v", resCfg.Id.ValueString()))
+	if err := r.deleteBackupFiles(ctx, stateCfg); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to Delete backup file, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(r.backupFleet(ctx, resCfg)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMFleetBackupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *CMFleetBackupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Deleting Fleet Backup : %s", stateCfg.Id.ValueString()))
+	if err := r.deleteBackupFiles(ctx, stateCfg); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to Delete backup file, got error: %s", err))
+		return
+	}
+	stateCfg.Id = types.StringValue("")
+}
+
+// backupFleet backs up the instances selected by the filter and sets backups and failed_count,
+// failed backups of single instances are reported as a warning
+func (r *CMFleetBackupResource) backupFleet(ctx context.Context, resCfg *CMFleetBackupResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	instances, err := r.getFleetInstances(ctx, resCfg.Filter)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read Device inventory, got error: %s", err))
+		return diags
+	}
+	if len(instances) == 0 {
+		diags.AddError("No Instances Found", "No instance in the Central Manager inventory matches the filter.")
+		return diags
+	}
+
+	results := make([]CMFleetBackupInstanceModel, len(instances))
+	sem := make(chan struct{}, resCfg.Parallelism.ValueInt64())
+	var wg sync.WaitGroup
+	for i, instance := range instances {
+		wg.Add(1)
+		go func(i int, instance cmFleetInstance) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = r.backupInstance(ctx, resCfg, instance)
+		}(i, instance)
+	}
+	wg.Wait()
+
+	var failures []string
+	for _, result := range results {
+		if result.Status.ValueString() == "failed" {
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Hostname.ValueString(), result.FailureReason.ValueString()))
+		}
+	}
+	if len(failures) == len(results) {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to create config backup of all instances, got errors:\n%s", strings.Join(failures, "\n")))
+		return diags
+	}
+	if len(failures) > 0 {
+		diags.AddWarning("Fleet Backup Incomplete", fmt.Sprintf("Failed to create config backup of %d of %d instances:\n%s", len(failures), len(results), strings.Join(failures, "\n")))
+	}
+	backupList, listDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: cmFleetBackupInstanceAttrTypes}, results)
+	diags.Append(listDiags...)
+	resCfg.Backups = backupList
+	resCfg.FailedCount = types.Int64Value(int64(len(failures)))
+	return diags
+}
+
+func (r *CMFleetBackupResource) backupInstance(ctx context.Context, resCfg *CMFleetBackupResourceModel, instance cmFleetInstance) CMFleetBackupInstanceModel {
+	name := instance.Hostname
+	if name == "" {
+		name = instance.Id
+	}
+	fileName := fmt.Sprintf("%s-%s.tar.gz", resCfg.FileNamePrefix.ValueString(), name)
+	result := CMFleetBackupInstanceModel{
+		InstanceId:    types.StringValue(instance.Id),
+		Hostname:      types.StringValue(instance.Hostname),
+		Address:       types.StringValue(instance.Address),
+		Version:       types.StringValue(instance.Version),
+		FileName:      types.StringValue(fileName),
+		BackupDate:    types.StringValue(""),
+		Status:        types.StringValue("completed"),
+		FailureReason: types.StringValue(""),
+	}
+	// file extensions are removed from the requested name, see getCreateBackupRestoreConfig
+	config := &bigipnextsdk.BackupRestoreTenantRequest{
+		FileName: removeExtensions(fileName),
+		Password: resCfg.BackupPassword.ValueString(),
+	}
+	tflog.Info(ctx, fmt.Sprintf("Backing up instance %s to %s", instance.Hostname, fileName))
+	unlock := lockInstanceBackup(instance.Id)
+	respData, err := r.client.BackupTenant(&instance.Id, config, int(resCfg.Timeout.ValueInt64()))
+	unlock()
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Backup of instance %s failed: %s", instance.Hostname, err))
+		result.Status = types.StringValue("failed")
+		result.FailureReason = types.StringValue(err.Error())
+		return result
+	}
+	if respData.FileName != "" {
+		result.FileName = types.StringValue(respData.FileName)
+	}
+	result.BackupDate = types.StringValue(respData.CreationDate)
+	return result
+}
+
+// instanceBackupLocks holds a lock per instance ID, see lockInstanceBackup
+var instanceBackupLocks sync.Map
+
+// lockInstanceBackup serializes the backups and restores of one instance, so fleet backups and
+// bigipnext_cm_device_backup_restore never run at the same time on it, and returns the function releasing the lock
+func lockInstanceBackup(instanceId string) func() {
+	lock, _ := instanceBackupLocks.LoadOrStore(instanceId, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+// cmSessionLocks holds a lock per CM client, see lockCMSession
+var cmSessionLocks sync.Map
+
+// lockCMSession serializes requests of the client that may refresh its token, the SDK refreshes the token of
+// the client on every request answered with an expired token, and returns the function releasing the lock
+func lockCMSession(client *bigipnextsdk.BigipNextCM) func() {
+	lock, _ := cmSessionLocks.LoadOrStore(client, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+// getFleetInstances returns the instances of the CM inventory matching the filter
+func (r *CMFleetBackupResource) getFleetInstances(ctx context.Context, filter types.Object) ([]cmFleetInstance, error) {
+	var versions []string
+	var hostnamePattern *regexp.Regexp
+	if !filter.IsNull() {
+		var filterCfg CMFleetBackupFilterModel
+		if diags := filter.As(ctx, &filterCfg, basetypes.ObjectAsOptions{}); diags.HasError() { // coverage-ignore
+			return nil, fmt.Errorf("invalid filter: %+v", diags.Errors())
+		}
+		if diags := filterCfg.Versions.ElementsAs(ctx, &versions, false); diags.HasError() { // coverage-ignore
+			return nil, fmt.Errorf("invalid filter versions: %+v", diags.Errors())
+		}
+		if !filterCfg.HostnamePattern.IsNull() {
+			var err error
+			if hostnamePattern, err = regexp.Compile(filterCfg.HostnamePattern.ValueString()); err != nil { // coverage-ignore
+				return nil, err
+			}
+		}
+	}
+
+	// the inventory is read by one fleet backup of the client at a time, an expired token is refreshed by the
+	// first of them and the instance backups started afterwards share the refreshed token
+	unlock := lockCMSession(r.client)
+	inventory, err := r.client.GetDeviceInventory()
+	unlock()
+	if err != nil {
+		return nil, err
+	}
+	var instances []cmFleetInstance
+	for _, device := range inventory.Embedded.Devices {
+		if len(versions) > 0 && !slices.Contains(versions, device.Version) {
+			continue
+		}
+		if hostnamePattern != nil && !hostnamePattern.MatchString(device.Hostname) {
+			continue
+		}
+		instances = append(instances, cmFleetInstance{
+			Id:       device.Id,
+			Hostname: device.Hostname,
+			Address:  device.Address,
+			Version:  device.Version,
+		})
+	}
+	tflog.Info(ctx, fmt.Sprintf("%d of %d instances selected for backup", len(instances), len(inventory.Embedded.Devices)))
+	return instances, nil
+}
+
+// deleteBackupFiles deletes the completed backup files of the fleet backup, files already removed are ignored
+func (r *CMFleetBackupResource) deleteBackupFiles(ctx context.Context, stateCfg *CMFleetBackupResourceModel) error {
+	var backups []CMFleetBackupInstanceModel
+	if diags := stateCfg.Backups.ElementsAs(ctx, &backups, false); diags.HasError() { // coverage-ignore
+		return fmt.Errorf("invalid backups: %+v", diags.Errors())
+	}
+	for _, backup := range backups {
+		if backup.Status.ValueString() != "completed" {
+			continue
+		}
+		fileName := backup.FileName.ValueString()
+		if err := r.client.DeleteBackupFile(&fileName); err != nil && !strings.Contains(err.Error(), `"code":404`) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,510 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource                   = &NextCMGSLBApplicationResource{}
+	_ resource.ResourceWithImportState    = &NextCMGSLBApplicationResource{}
+	_ resource.ResourceWithValidateConfig = &NextCMGSLBApplicationResource{}
+	_ resource.ResourceWithModifyPlan     = &NextCMGSLBApplicationResource{}
+)
+
+func NewNextCMGSLBApplicationResource() resource.Resource {
+	return &NextCMGSLBApplicationResource{}
+}
+
+type NextCMGSLBApplicationResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type NextCMGSLBApplicationResourceModel struct {
+	Name        types.String    `tfsdk:"name"`
+	Description types.String    `tfsdk:"description"`
+	GRGroupId   types.String    `tfsdk:"gr_group_id"`
+	Fqdns       []GSLBFqdnModel `tfsdk:"fqdns"`
+	Pools       []GSLBPoolModel `tfsdk:"pools"`
+	Timeout     types.Int64     `tfsdk:"timeout"`
+	Status      types.String    `tfsdk:"status"`
+	Id          types.String    `tfsdk:"id"`
+}
+
+type GSLBFqdnModel struct {
+	Name              types.String   `tfsdk:"name"`
+	RecordType        types.String   `tfsdk:"record_type"`
+	LoadBalancingMode types.String   `tfsdk:"load_balancing_mode"`
+	TTL               types.Int64    `tfsdk:"ttl"`
+	Pools             []types.String `tfsdk:"pools"`
+}
+
+type GSLBPoolModel struct {
+	Name              types.String          `tfsdk:"name"`
+	LoadBalancingMode types.String          `tfsdk:"load_balancing_mode"`
+	Monitors          []types.String        `tfsdk:"monitors"`
+	Members           []GSLBPoolMemberModel `tfsdk:"members"`
+}
+
+type GSLBPoolMemberModel struct {
+	InstanceAddress types.String `tfsdk:"instance_address"`
+	VirtualServer   types.String `tfsdk:"virtual_server"`
+	Ratio           types.Int64  `tfsdk:"ratio"`
+}
+
+var gslbLoadBalancingModes = []string{"round-robin", "ratio", "global-availability", "topology"}
+
+func (r *NextCMGSLBApplicationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_gslb_application"
+}
+
+func (r *NextCMGSLBApplicationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to manage GSLB applications on BIG-IP Next Central Manager. A GSLB application answers DNS queries " +
+			"for its FQDNs (wide IPs) from pools of virtual servers of the instances of a Global Resiliency Group, " +
+			"such as one created with `bigipnext_cm_global_resiliency`.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the GSLB application.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description of the GSLB application.",
+			},
+			"gr_group_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the Global Resiliency Group the application is deployed to, such as `bigipnext_cm_global_resiliency.example.id`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fqdns": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "FQDNs (wide IPs) answered by the application.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Fully qualified domain name, such as `www.example.com`.",
+						},
+						"record_type": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "DNS record type answered for the FQDN, `A` or `AAAA`. Default is `A`.",
+							Default:             stringdefault.StaticString("A"),
+							Validators: []validator.String{
+								stringvalidator.OneOf("A", "AAAA"),
+							},
+						},
+						"load_balancing_mode": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "Method used to select a pool of the FQDN, one of `round-robin`, `ratio`, `global-availability` or `topology`. Default is `round-robin`.",
+							Default:             stringdefault.StaticString("round-robin"),
+							Validators: []validator.String{
+								stringvalidator.OneOf(gslbLoadBalancingModes...),
+							},
+						},
+						"ttl": schema.Int64Attribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "Time to live of the DNS answers, in seconds. Default is `30`.",
+							Default:             int64default.StaticInt64(30),
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"pools": schema.ListAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Names of the pools of `pools` answering for the FQDN, in order of preference.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"pools": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Pools of virtual servers across the instances of the Global Resiliency Group.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the pool.",
+						},
+						"load_balancing_mode": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							MarkdownDescription: "Method used to select a member of the pool, one of `round-robin`, `ratio`, `global-availability` or `topology`. Default is `round-robin`.",
+							Default:             stringdefault.StaticString("round-robin"),
+							Validators: []validator.String{
+								stringvalidator.OneOf(gslbLoadBalancingModes...),
+							},
+						},
+						"monitors": schema.ListAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Names of the GSLB monitors checking the members of the pool, such as `bigipnext_cm_gslb_monitor.example.name`.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+						},
+						"members": schema.ListNestedAttribute{
+							Required:            true,
+							MarkdownDescription: "Virtual servers of the pool.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"instance_address": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "Address of the instance of the Global Resiliency Group hosting the virtual server.",
+									},
+									"virtual_server": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "Name of the virtual server on the instance.",
+									},
+									"ratio": schema.Int64Attribute{
+										Optional:            true,
+										Computed:            true,
+										MarkdownDescription: "Weight of the member with the `ratio` load balancing mode. Default is `1`.",
+										Default:             int64default.StaticInt64(1),
+										Validators: []validator.Int64{
+											int64validator.AtLeast(1),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The number of seconds to wait for the application to be deployed.",
+				Default:             int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Deployment status of the application, such as `DEPLOYED`.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique Identifier for the resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NextCMGSLBApplicationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var pools, fqdns types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pools"), &pools)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("fqdns"), &fqdns)...)
+	if resp.Diagnostics.HasError() || pools.IsNull() || pools.IsUnknown() || fqdns.IsNull() || fqdns.IsUnknown() {
+		return
+	}
+	var poolNames []string
+	for i, pool := range pools.Elements() {
+		pool, ok := pool.(types.Object)
+		if !ok || pool.IsUnknown() {
+			return
+		}
+		name, ok := pool.Attributes()["name"].(types.String)
+		if !ok || name.IsUnknown() {
+			return
+		}
+		if slices.Contains(poolNames, name.ValueString()) {
+			resp.Diagnostics.AddAttributeError(path.Root("pools").AtListIndex(i).AtName("name"), "Duplicate Pool", fmt.Sprintf("Pool %s is defined more than once", name.ValueString()))
+		}
+		poolNames = append(poolNames, name.ValueString())
+	}
+	for i, fqdn := range fqdns.Elements() {
+		fqdn, ok := fqdn.(types.Object)
+		if !ok || fqdn.IsUnknown() {
+			continue
+		}
+		fqdnPools, ok := fqdn.Attributes()["pools"].(types.List)
+		if !ok || fqdnPools.IsUnknown() {
+			continue
+		}
+		for j, pool := range fqdnPools.Elements() {
+			pool, ok := pool.(types.String)
+			if ok && !pool.IsUnknown() && !slices.Contains(poolNames, pool.ValueString()) {
+				resp.Diagnostics.AddAttributeError(path.Root("fqdns").AtListIndex(i).AtName("pools").AtListIndex(j), "Unknown Pool", fmt.Sprintf("Pool %s is not defined in pools", pool.ValueString()))
+			}
+		}
+	}
+}
+
+func (r *NextCMGSLBApplicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var grGroupId types.String
+	var pools types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("gr_group_id"), &grGroupId)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pools"), &pools)...)
+	if resp.Diagnostics.HasError() || grGroupId.IsUnknown() {
+		// the members are validated once the Global Resiliency Group is known
+		return
+	}
+	poolsValue, err := pools.ToTerraformValue(ctx)
+	if err != nil || !poolsValue.IsFullyKnown() {
+		return
+	}
+	var poolModels []GSLBPoolModel
+	resp.Diagnostics.Append(pools.ElementsAs(ctx, &poolModels, false)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	resp.Diagnostics.Append(r.validateMembers(ctx, grGroupId.ValueString(), poolModels)...)
+}
+
+func (r *NextCMGSLBApplicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *NextCMGSLBApplicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *NextCMGSLBApplicationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	reqDraft := getGSLBApplicationRequestDraft(resCfg)
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] GSLB application: %+v", reqDraft))
+	application, err := postGSLBApplication(r.client, "POST", reqDraft, resCfg.Timeout.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Create GSLB application, got error: %s", err))
+		return
+	}
+	gslbApplicationModelToState(application, resCfg)
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *NextCMGSLBApplicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *NextCMGSLBApplicationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	id := stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[READ] Reading GSLB application : %s", id))
+	application, err := getGSLBApplication(r.client, id)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Warn(ctx, fmt.Sprintf("[READ] GSLB application %s not found, removing from state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read GSLB application, got error: %s", err))
+		return
+	}
+	if stateCfg.Timeout.IsNull() {
+		// imported
+		stateCfg.Timeout = types.Int64Value(300)
+	}
+	gslbApplicationModelToState(application, stateCfg)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *NextCMGSLBApplicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg, stateCfg *NextCMGSLBApplicationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	reqDraft := getGSLBApplicationRequestDraft(resCfg)
+	reqDraft.Id = stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[UPDATE] GSLB application: %+v", reqDraft))
+	application, err := postGSLBApplication(r.client, "PUT", reqDraft, resCfg.Timeout.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Update GSLB application, got error: %s", err))
+		return
+	}
+	gslbApplicationModelToState(application, resCfg)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
+}
+
+func (r *NextCMGSLBApplicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *NextCMGSLBApplicationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	id := stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[DELETE] Deleting GSLB application : %s", id))
+	if err := deleteGSLBApplication(r.client, id); err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Delete GSLB application, got error: %s", err))
+		return
+	}
+}
+
+func (r *NextCMGSLBApplicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// validateMembers warns about the pool members which are not virtual servers of instances of the Global Resiliency
+// Group. The group is read from CM, so instances added to the group in the same apply are not known yet and only
+// a warning is raised
+func (r *NextCMGSLBApplicationResource) validateMembers(ctx context.Context, grGroupId string, pools []GSLBPoolModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	grData, err := r.client.GetGlobalResiliencyGroupDetails(grGroupId)
+	if err != nil {
+		diags.AddWarning("Unable to validate GSLB pool members", fmt.Sprintf("Failed to Read Global Resiliency Group %s, got error: %s", grGroupId, err))
+		return diags
+	}
+	var addresses []string
+	instances, _ := grData.(map[string]interface{})["instances"].([]interface{})
+	for _, instance := range instances {
+		if instance, ok := instance.(map[string]interface{}); ok {
+			addresses = append(addresses, grString(instance, "address"))
+		}
+	}
+	tflog.Info(ctx, fmt.Sprintf("Instances of Global Resiliency Group %s: %v", grGroupId, addresses))
+	for i, pool := range pools {
+		for j, member := range pool.Members {
+			if !slices.Contains(addresses, member.InstanceAddress.ValueString()) {
+				diags.AddAttributeWarning(path.Root("pools").AtListIndex(i).AtName("members").AtListIndex(j).AtName("instance_address"), "Instance Not In Group",
+					fmt.Sprintf("Instance %s of pool %s is not an instance of Global Resiliency Group %s, the deployment fails unless the instance is added to the group in the same apply",
+						member.InstanceAddress.ValueString(), pool.Name.ValueString(), grGroupId))
+			}
+		}
+	}
+	return diags
+}
+
+func getGSLBApplicationRequestDraft(data *NextCMGSLBApplicationResourceModel) *gslbApplication {
+	application := &gslbApplication{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		GRGroupId:   data.GRGroupId.ValueString(),
+	}
+	for _, fqdn := range data.Fqdns {
+		fqdnDraft := gslbFqdn{
+			Name:              fqdn.Name.ValueString(),
+			RecordType:        fqdn.RecordType.ValueString(),
+			LoadBalancingMode: fqdn.LoadBalancingMode.ValueString(),
+			TTL:               fqdn.TTL.ValueInt64(),
+		}
+		for _, pool := range fqdn.Pools {
+			fqdnDraft.Pools = append(fqdnDraft.Pools, pool.ValueString())
+		}
+		application.Fqdns = append(application.Fqdns, fqdnDraft)
+	}
+	for _, pool := range data.Pools {
+		poolDraft := gslbPool{
+			Name:              pool.Name.ValueString(),
+			LoadBalancingMode: pool.LoadBalancingMode.ValueString(),
+		}
+		for _, monitor := range pool.Monitors {
+			poolDraft.Monitors = append(poolDraft.Monitors, monitor.ValueString())
+		}
+		for _, member := range pool.Members {
+			poolDraft.Members = append(poolDraft.Members, gslbPoolMember{
+				InstanceAddress: member.InstanceAddress.ValueString(),
+				VirtualServer:   member.VirtualServer.ValueString(),
+				Ratio:           member.Ratio.ValueInt64(),
+			})
+		}
+		application.Pools = append(application.Pools, poolDraft)
+	}
+	return application
+}
+
+// gslbApplicationModelToState sets data from application, CM omits attributes set to their default
+func gslbApplicationModelToState(application *gslbApplication, data *NextCMGSLBApplicationResourceModel) {
+	data.Id = types.StringValue(application.Id)
+	data.Name = types.StringValue(application.Name)
+	data.GRGroupId = types.StringValue(application.GRGroupId)
+	if application.Description != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(application.Description)
+	}
+	data.Status = types.StringValue(application.Status)
+
+	data.Fqdns = nil
+	for _, fqdn := range application.Fqdns {
+		fqdnModel := GSLBFqdnModel{
+			Name:              types.StringValue(fqdn.Name),
+			RecordType:        types.StringValue(gslbDefaultString(fqdn.RecordType, "A")),
+			LoadBalancingMode: types.StringValue(gslbDefaultString(fqdn.LoadBalancingMode, "round-robin")),
+			TTL:               types.Int64Value(gslbDefaultInt64(fqdn.TTL, 30)),
+			Pools:             []types.String{},
+		}
+		for _, pool := range fqdn.Pools {
+			fqdnModel.Pools = append(fqdnModel.Pools, types.StringValue(pool))
+		}
+		data.Fqdns = append(data.Fqdns, fqdnModel)
+	}
+	data.Pools = nil
+	for _, pool := range application.Pools {
+		poolModel := GSLBPoolModel{
+			Name:              types.StringValue(pool.Name),
+			LoadBalancingMode: types.StringValue(gslbDefaultString(pool.LoadBalancingMode, "round-robin")),
+			Members:           []GSLBPoolMemberModel{},
+		}
+		for _, monitor := range pool.Monitors {
+			poolModel.Monitors = append(poolModel.Monitors, types.StringValue(monitor))
+		}
+		for _, member := range pool.Members {
+			poolModel.Members = append(poolModel.Members, GSLBPoolMemberModel{
+				InstanceAddress: types.StringValue(member.InstanceAddress),
+				VirtualServer:   types.StringValue(member.VirtualServer),
+				Ratio:           types.Int64Value(gslbDefaultInt64(member.Ratio, 1)),
+			})
+		}
+		data.Pools = append(data.Pools, poolModel)
+	}
+}
+
+func gslbDefaultString(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func gslbDefaultInt64(value, defaultValue int64) int64 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+const (
+	uriGSLBApplications = "/v1/spaces/default/gslb/applications"
+	uriGSLBMonitors     = "/v1/spaces/default/gslb/monitors"
+	uriGRGroups         = "/v1/spaces/default/gslb/gr-groups"
+	uriActiveDNSAlert   = "/alert/v1/alerts/?limit=1&sort=-start_time&filter=source%20eq%20%27DNS%27%20and%20status%20eq%20%27ACTIVE%27&select=summary"
+)
+
+// gslbApplication is a GSLB application deployed to the instances of a Global Resiliency Group
+type gslbApplication struct {
+	Id          string     `json:"id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	GRGroupId   string     `json:"gr_group_id,omitempty"`
+	Fqdns       []gslbFqdn `json:"fqdns,omitempty"`
+	Pools       []gslbPool `json:"pools,omitempty"`
+	Status      string     `json:"status,omitempty"`
+}
+
+// gslbFqdn is a wide IP of a GSLB application, answered from its pools
+type gslbFqdn struct {
+	Name              string   `json:"name"`
+	RecordType        string   `json:"record_type,omitempty"`
+	LoadBalancingMode string   `json:"load_balancing_mode,omitempty"`
+	TTL               int64    `json:"ttl,omitempty"`
+	Pools             []string `json:"pools"`
+}
+
+// gslbPool is a pool of virtual servers across the instances of the Global Resiliency Group
+type gslbPool struct {
+	Name              string           `json:"name"`
+	LoadBalancingMode string           `json:"load_balancing_mode,omitempty"`
+	Monitors          []string         `json:"monitors,omitempty"`
+	Members           []gslbPoolMember `json:"members"`
+}
+
+// gslbPoolMember is a virtual server of an instance of the Global Resiliency Group
+type gslbPoolMember struct {
+	InstanceAddress string `json:"instance_address"`
+	VirtualServer   string `json:"virtual_server"`
+	Ratio           int64  `json:"ratio,omitempty"`
+}
+
+// gslbMonitor is a health monitor of GSLB pool members
+type gslbMonitor struct {
+	Id       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Interval int64  `json:"interval,omitempty"`
+	Timeout  int64  `json:"timeout,omitempty"`
+	Send     string `json:"send,omitempty"`
+	Receive  string `json:"receive,omitempty"`
+}
+
+// postGSLBApplication creates (POST) or updates (PUT) the GSLB application, polls it until it is deployed,
+// for up to timeout seconds, and returns the deployed application
+// /api/v1/spaces/default/gslb/applications
+func postGSLBApplication(client *bigipnextsdk.BigipNextCM, op string, config *gslbApplication, timeout int64) (*gslbApplication, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var respData []byte
+	if op == "PUT" {
+		respData, err = client.PutCMRequest(fmt.Sprintf("%s/%s", uriGSLBApplications, config.Id), body)
+	} else {
+		respData, err = client.PostCMRequest(uriGSLBApplications, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var respInfo struct {
+		Id   string `json:"id"`
+		Path string `json:"path"`
+	}
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return nil, err
+	}
+	id := respInfo.Id
+	if id == "" && respInfo.Path != "" {
+		pathList := strings.Split(respInfo.Path, "/")
+		id = pathList[len(pathList)-1]
+	}
+	if id == "" {
+		return nil, fmt.Errorf("no application id in the response of the GSLB application request: %s", string(respData))
+	}
+	return waitGSLBApplication(client, id, timeout)
+}
+
+// waitGSLBApplication polls the GSLB application until it is deployed or failed, for up to timeout seconds
+func waitGSLBApplication(client *bigipnextsdk.BigipNextCM, id string, timeout int64) (*gslbApplication, error) {
+	taskTimeout := time.Duration(timeout) * time.Second
+	endtime := time.Now().Add(taskTimeout)
+	for {
+		application, err := getGSLBApplication(client, id)
+		if err != nil {
+			return nil, err
+		}
+		// an application without status is not deployed yet, it is polled until it reports DEPLOYED or FAILED
+		switch application.Status {
+		case "DEPLOYED":
+			return application, nil
+		case "FAILED":
+			return nil, getDNSAlertMessage(client)
+		}
+		if !time.Now().Before(endtime) {
+			return nil, fmt.Errorf("GSLB application %s is still in :%+v within timeout period of:%+v", id, application.Status, taskTimeout)
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// getGSLBApplication returns the details of the GSLB application
+// /api/v1/spaces/default/gslb/applications/{id}
+func getGSLBApplication(client *bigipnextsdk.BigipNextCM, id string) (*gslbApplication, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s/%s", uriGSLBApplications, id))
+	if err != nil {
+		return nil, err
+	}
+	var application gslbApplication
+	if err = json.Unmarshal(respData, &application); err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// deleteGSLBApplication deletes the GSLB application, an application which is already gone is not an error
+func deleteGSLBApplication(client *bigipnextsdk.BigipNextCM, id string) error {
+	_, err := client.DeleteCMRequest(fmt.Sprintf("%s/%s", uriGSLBApplications, id))
+	if err != nil && !strings.Contains(err.Error(), `"code":404`) {
+		return err
+	}
+	return nil
+}
+
+// postGSLBMonitor creates (POST) or updates (PUT) the GSLB monitor
+// /api/v1/spaces/default/gslb/monitors
+func postGSLBMonitor(client *bigipnextsdk.BigipNextCM, op string, config *gslbMonitor) (*gslbMonitor, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var respData []byte
+	if op == "PUT" {
+		respData, err = client.PutCMRequest(fmt.Sprintf("%s/%s", uriGSLBMonitors, config.Id), body)
+	} else {
+		respData, err = client.PostCMRequest(uriGSLBMonitors, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var monitor gslbMonitor
+	if err = json.Unmarshal(respData, &monitor); err != nil {
+		return nil, err
+	}
+	if monitor.Id == "" {
+		return nil, fmt.Errorf("no monitor id in the response of the GSLB monitor request: %s", string(respData))
+	}
+	return &monitor, nil
+}
+
+// getGSLBMonitor returns the details of the GSLB monitor
+// /api/v1/spaces/default/gslb/monitors/{id}
+func getGSLBMonitor(client *bigipnextsdk.BigipNextCM, id string) (*gslbMonitor, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s/%s", uriGSLBMonitors, id))
+	if err != nil {
+		return nil, err
+	}
+	var monitor gslbMonitor
+	if err = json.Unmarshal(respData, &monitor); err != nil {
+		return nil, err
+	}
+	return &monitor, nil
+}
+
+// deleteGSLBMonitor deletes the GSLB monitor, a monitor which is already gone is not an error
+func deleteGSLBMonitor(client *bigipnextsdk.BigipNextCM, id string) error {
+	_, err := client.DeleteCMRequest(fmt.Sprintf("%s/%s", uriGSLBMonitors, id))
+	if err != nil && !strings.Contains(err.Error(), `"code":404`) {
+		return err
+	}
+	return nil
+}
+
+// postGlobalResiliencyGroup creates (POST) or updates (PUT) the Global Resiliency Group, polls its task until
+// the group is deployed, for up to timeout seconds, and returns the deployed group
+// /api/v1/spaces/default/gslb/gr-groups
+func postGlobalResiliencyGroup(client *bigipnextsdk.BigipNextCM, op string, config *bigipnextsdk.GlobalResiliencyRequestDraft, timeout int64) (map[string]interface{}, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var respData []byte
+	if op == "PUT" {
+		respData, err = client.PutCMRequest(fmt.Sprintf("%s/%s", uriGRGroups, config.Id), body)
+	} else {
+		respData, err = client.PostCMRequest(uriGRGroups, body)
+	}
+	if err != nil {
+		return nil, err
+	}
+	respInfo := make(map[string]interface{})
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return nil, err
+	}
+	taskPath, ok := respInfo["path"].(string)
+	if !ok || taskPath == "" {
+		return nil, fmt.Errorf("no task path in the response of the global resiliency group request: %s", string(respData))
+	}
+	pathList := strings.Split(taskPath, "/")
+	return getGlobalResiliencyTaskStatus(client, pathList[len(pathList)-1], timeout)
+}
+
+// getGlobalResiliencyTaskStatus polls the Global Resiliency Group until it is deployed or failed, for up to timeout seconds
+// /api/v1/spaces/default/gslb/gr-groups/{id}
+func getGlobalResiliencyTaskStatus(client *bigipnextsdk.BigipNextCM, taskId string, timeout int64) (map[string]interface{}, error) {
+	taskTimeout := time.Duration(timeout) * time.Second
+	endtime := time.Now().Add(taskTimeout)
+	for {
+		respData, err := client.GetCMRequest(fmt.Sprintf("%s/%s", uriGRGroups, taskId))
+		if err != nil {
+			return nil, err
+		}
+		var respInfo map[string]interface{}
+		if err = json.Unmarshal(respData, &respInfo); err != nil {
+			return nil, err
+		}
+		status, _ := respInfo["status"].(string)
+		if status == "DEPLOYED" {
+			return respInfo, nil
+		}
+		if status == "FAILED" {
+			return nil, getDNSAlertMessage(client)
+		}
+		if !time.Now().Before(endtime) {
+			return nil, fmt.Errorf("task status is still in :%+v within timeout period of:%+v", status, taskTimeout)
+		}
+		time.Sleep(10 * time.Second)
+	}
+}
+
+// deleteGlobalResiliencyGroup deletes the Global Resiliency Group and waits up to timeout seconds until it is gone
+// /api/v1/spaces/default/gslb/gr-groups/{id}
+func deleteGlobalResiliencyGroup(client *bigipnextsdk.BigipNextCM, id string, timeout int64) error {
+	groupUrl := fmt.Sprintf("%s/%s", uriGRGroups, id)
+	if _, err := client.DeleteCMRequest(groupUrl); err != nil {
+		return err
+	}
+	taskTimeout := time.Duration(timeout) * time.Second
+	endtime := time.Now().Add(taskTimeout)
+	for time.Now().Before(endtime) {
+		if _, err := client.GetCMRequest(groupUrl); err != nil && strings.Contains(err.Error(), "Global Resiliency Group not found") {
+			return nil
+		}
+		time.Sleep(10 * time.Second)
+	}
+	return fmt.Errorf("task status is still in Running State within timeout period of:%+v", taskTimeout)
+}
+
+// getDNSAlertMessage returns the error of a failed GSLB task, with the summary of the latest active DNS alert
+// of CM when there is one
+// /api/alert/v1/alerts/?limit=1&sort=-start_time&filter=source eq 'DNS' and status eq 'ACTIVE'&select=summary
+func getDNSAlertMessage(client *bigipnextsdk.BigipNextCM) error {
+	respData, err := client.GetCMRequest(uriActiveDNSAlert)
+	if err != nil {
+		return fmt.Errorf("task failed, unable to read the alert summary: %v", err)
+	}
+	var respInfo struct {
+		Embedded struct {
+			Alerts []struct {
+				Summary string `json:"summary"`
+			} `json:"alerts"`
+		} `json:"_embedded"`
+	}
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return fmt.Errorf("task failed, unable to parse the alert summary: %v", err)
+	}
+	if len(respInfo.Embedded.Alerts) == 0 || respInfo.Embedded.Alerts[0].Summary == "" {
+		return fmt.Errorf("task failed, no active alert on CM describes the failure")
+	}
+	return fmt.Errorf("task failed, summary : %+v ", respInfo.Embedded.Alerts[0].Summary)
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource                = &NextCMGSLBMonitorResource{}
+	_ resource.ResourceWithImportState = &NextCMGSLBMonitorResource{}
+)
+
+func NewNextCMGSLBMonitorResource() resource.Resource {
+	return &NextCMGSLBMonitorResource{}
+}
+
+type NextCMGSLBMonitorResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type NextCMGSLBMonitorResourceModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Interval types.Int64  `tfsdk:"interval"`
+	Timeout  types.Int64  `tfsdk:"timeout"`
+	Send     types.String `tfsdk:"send"`
+	Receive  types.String `tfsdk:"receive"`
+	Id       types.String `tfsdk:"id"`
+}
+
+func (r *NextCMGSLBMonitorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_gslb_monitor"
+}
+
+func (r *NextCMGSLBMonitorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to manage health monitors of the pool members of `bigipnext_cm_gslb_application` on BIG-IP Next Central Manager.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the GSLB monitor.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Type of the GSLB monitor, one of `http`, `https`, `tcp`, `udp` or `icmp`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("http", "https", "tcp", "udp", "icmp"),
+				},
+			},
+			"interval": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Number of seconds between two checks of a member. Default is `30`.",
+				Default:             int64default.StaticInt64(30),
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Number of seconds after which a member not answering the checks is marked down. Default is `120`.",
+				Default:             int64default.StaticInt64(120),
+			},
+			"send": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Request sent to the members by `http`, `https`, `tcp` and `udp` monitors, such as `GET / HTTP/1.0\\r\\n\\r\\n`.",
+			},
+			"receive": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Expected response of the members to `send`, as a regular expression.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique Identifier for the resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NextCMGSLBMonitorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *NextCMGSLBMonitorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *NextCMGSLBMonitorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	reqDraft := getGSLBMonitorRequestDraft(resCfg)
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] GSLB monitor: %+v", reqDraft))
+	monitor, err := postGSLBMonitor(r.client, "POST", reqDraft)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Create GSLB monitor, got error: %s", err))
+		return
+	}
+	resCfg.Id = types.StringValue(monitor.Id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *NextCMGSLBMonitorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *NextCMGSLBMonitorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	id := stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[READ] Reading GSLB monitor : %s", id))
+	monitor, err := getGSLBMonitor(r.client, id)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Warn(ctx, fmt.Sprintf("[READ] GSLB monitor %s not found, removing from state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read GSLB monitor, got error: %s", err))
+		return
+	}
+	stateCfg.Id = types.StringValue(monitor.Id)
+	stateCfg.Name = types.StringValue(monitor.Name)
+	stateCfg.Type = types.StringValue(monitor.Type)
+	stateCfg.Interval = types.Int64Value(gslbDefaultInt64(monitor.Interval, 30))
+	stateCfg.Timeout = types.Int64Value(gslbDefaultInt64(monitor.Timeout, 120))
+	if monitor.Send != "" || !stateCfg.Send.IsNull() {
+		stateCfg.Send = types.StringValue(monitor.Send)
+	}
+	if monitor.Receive != "" || !stateCfg.Receive.IsNull() {
+		stateCfg.Receive = types.StringValue(monitor.Receive)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *NextCMGSLBMonitorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg, stateCfg *NextCMGSLBMonitorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	reqDraft := getGSLBMonitorRequestDraft(resCfg)
+	reqDraft.Id = stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[UPDATE] GSLB monitor: %+v", reqDraft))
+	if _, err := postGSLBMonitor(r.client, "PUT", reqDraft); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Update GSLB monitor, got error: %s", err))
+		return
+	}
+	resCfg.Id = stateCfg.Id
+	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
+}
+
+func (r *NextCMGSLBMonitorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *NextCMGSLBMonitorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	id := stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[DELETE] Deleting GSLB monitor : %s", id))
+	if err := deleteGSLBMonitor(r.client, id); err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Delete GSLB monitor, got error: %s", err))
+		return
+	}
+}
+
+func (r *NextCMGSLBMonitorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func getGSLBMonitorRequestDraft(data *NextCMGSLBMonitorResourceModel) *gslbMonitor {
+	return &gslbMonitor{
+		Name:     data.Name.ValueString(),
+		Type:     data.Type.ValueString(),
+		Interval: data.Interval.ValueInt64(),
+		Timeout:  data.Timeout.ValueInt64(),
+		Send:     data.Send.ValueString(),
+		Receive:  data.Receive.ValueString(),
+	}
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+const (
+	uriNextInstances   = "/v1/spaces/default/instances"
+	uriDeviceInventory = "/device/v1/inventory"
+)
+
+// nextInstance is a NEXT instance of the CM inventory, an HA cluster is listed with mode HA
+type nextInstance struct {
+	Address      string `json:"address,omitempty"`
+	Hostname     string `json:"hostname,omitempty"`
+	Id           string `json:"id,omitempty"`
+	Mode         string `json:"mode,omitempty"`
+	Version      string `json:"version,omitempty"`
+	PlatformName string `json:"platform_name,omitempty"`
+	PlatformType string `json:"platform_type,omitempty"`
+	ShortId      string `json:"short_id,omitempty"`
+}
+
+// nextInstanceDiskUsage is the disk usage of a NEXT instance in bytes
+type nextInstanceDiskUsage struct {
+	Total     int64 `json:"total,omitempty"`
+	Used      int64 `json:"used,omitempty"`
+	Available int64 `json:"available,omitempty"`
+}
+
+// getNextInstance returns the inventory entry of the NEXT instance with given management IP
+// /api/v1/spaces/default/instances
+func getNextInstance(client *bigipnextsdk.BigipNextCM, ipAddr string) (*nextInstance, error) {
+	respData, err := client.GetCMRequest(uriNextInstances)
+	if err != nil {
+		return nil, err
+	}
+	var instances struct {
+		Embedded struct {
+			Devices []nextInstance `json:"devices,omitempty"`
+		} `json:"_embedded,omitempty"`
+	}
+	if err = json.Unmarshal(respData, &instances); err != nil {
+		return nil, err
+	}
+	for _, instance := range instances.Embedded.Devices {
+		if instance.Address == ipAddr {
+			return &instance, nil
+		}
+	}
+	return nil, fmt.Errorf("NEXT instance with IP %v not found", ipAddr)
+}
+
+// getNextInstanceDiskUsage returns the disk usage of the NEXT instance, Total is zero when the instance does not
+// report it. The system/disk-usage path is not available on every NEXT version, the proxy answers 404 when the
+// instance does not support it
+// /api/device/v1/proxy/{id}?path=/system/disk-usage
+func getNextInstanceDiskUsage(client *bigipnextsdk.BigipNextCM, instanceId string) (*nextInstanceDiskUsage, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s/%s?path=%s", uriCMProxy, instanceId, url.QueryEscape("/system/disk-usage")))
+	if err != nil {
+		return nil, err
+	}
+	diskUsage := &nextInstanceDiskUsage{}
+	if err = json.Unmarshal(respData, diskUsage); err != nil {
+		return nil, err
+	}
+	return diskUsage, nil
+}
+
+// getDeviceHA returns the HA configuration of the NEXT HA cluster with the current active and standby instance IDs
+// /api/device/v1/inventory/{id}/ha
+func getDeviceHA(client *bigipnextsdk.BigipNextCM, clusterId string) (*bigipnextsdk.HATaskResp, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s/%s/ha", uriDeviceInventory, clusterId))
+	if err != nil {
+		return nil, err
+	}
+	haInfo := &bigipnextsdk.HATaskResp{}
+	if err = json.Unmarshal(respData, haInfo); err != nil {
+		return nil, err
+	}
+	return haInfo, nil
+}
+
+// failoverDeviceHA makes the standby instance of the NEXT HA cluster active and waits up to timeOut seconds
+// until CM reports the swapped roles
+// /api/device/v1/inventory/{id}/ha/failover
+func failoverDeviceHA(client *bigipnextsdk.BigipNextCM, clusterId string, timeOut int) (*bigipnextsdk.HATaskResp, error) {
+	haInfo, err := getDeviceHA(client, clusterId)
+	if err != nil {
+		return nil, err
+	}
+	formerStandby := haInfo.StandbyInstanceId
+	if _, err = client.PostCMRequest(fmt.Sprintf("%s/%s/ha/failover", uriDeviceInventory, clusterId), []byte("{}")); err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(timeOut) * time.Second
+	endtime := time.Now().Add(timeout)
+	for time.Now().Before(endtime) {
+		haInfo, err = getDeviceHA(client, clusterId)
+		if err != nil {
+			return nil, err
+		}
+		if haInfo.ActiveInstanceId == formerStandby {
+			return haInfo, nil
+		}
+		// poll every tenth of the timeout, at least every second and not past the timeout
+		interval := time.Duration(timeOut/10) * time.Second
+		if interval < time.Second {
+			interval = time.Second
+		}
+		if remaining := time.Until(endtime); interval > remaining {
+			interval = remaining
+		}
+		time.Sleep(interval)
+	}
+	return nil, fmt.Errorf("instance %s did not become active within timeout period of:%+v", formerStandby, timeout)
+}
+
+// checkNextInstanceHealth calls the API path of the NEXT instance through the CM proxy, an error is returned when
+// the instance does not answer with success
+// /api/device/v1/proxy/{id}?path={apiPath}
+func checkNextInstanceHealth(client *bigipnextsdk.BigipNextCM, instanceId, apiPath string) error {
+	_, err := client.GetCMRequest(fmt.Sprintf("%s/%s?path=%s", uriCMProxy, instanceId, url.QueryEscape(apiPath)))
+	return err
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMInstanceLicenseDataSource{}
+)
+
+func NewCMInstanceLicenseDataSource() datasource.DataSource {
+	return &CMInstanceLicenseDataSource{}
+}
+
+// CMInstanceLicenseDataSource defines the data source implementation.
+type CMInstanceLicenseDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMInstanceLicenseDataSourceModel describes the data source data model.
+type CMInstanceLicenseDataSourceModel struct {
+	ID                types.String                   `tfsdk:"id"`
+	InstanceAddresses types.List                     `tfsdk:"instance_addresses"`
+	Hostnames         types.List                     `tfsdk:"hostnames"`
+	Instances         []CMInstanceLicenseSourceModel `tfsdk:"instances"`
+}
+
+type CMInstanceLicenseSourceModel struct {
+	InstanceId          types.String          `tfsdk:"instance_id"`
+	InstanceAddress     types.String          `tfsdk:"instance_address"`
+	Hostname            types.String          `tfsdk:"hostname"`
+	LicenseStatus       types.String          `tfsdk:"license_status"`
+	LicenseSubStatus    types.String          `tfsdk:"license_sub_status"`
+	ExpiryDate          types.String          `tfsdk:"expiry_date"`
+	DaysUntilExpiry     types.Int64           `tfsdk:"days_until_expiry"`
+	SubscriptionType    types.String          `tfsdk:"subscription_type"`
+	SubscriptionSubType types.String          `tfsdk:"subscription_sub_type"`
+	TokenId             types.String          `tfsdk:"token_id"`
+	TokenName           types.String          `tfsdk:"token_name"`
+	Features            []LicenseFeatureModel `tfsdk:"features"`
+}
+
+type deviceLicenseInfo struct {
+	DeviceLicenseStatus struct {
+		EnabledFeatures  string `json:"enabledFeatures"`
+		ExpiryDate       string `json:"expiryDate"`
+		LicenseStatus    string `json:"licenseStatus"`
+		LicenseSubStatus string `json:"licenseSubStatus"`
+		LicenseToken     struct {
+			TokenId   string `json:"tokenId"`
+			TokenName string `json:"tokenName"`
+		} `json:"licenseToken"`
+		SubscriptionSubType string `json:"subscriptionSubType"`
+		SubscriptionType    string `json:"subscriptionType"`
+	} `json:"deviceLicenseStatus"`
+}
+
+func (d *CMInstanceLicenseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_instance_license"
+}
+
+func (d *CMInstanceLicenseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the license status and usage of BIG-IP Next instances managed by Central Manager.\n\n" +
+			"Instances are selected by `instance_addresses` and `hostnames`, all instances are returned when neither is set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this data source.",
+			},
+			"instance_addresses": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Management addresses of the instances to get the license of.",
+			},
+			"hostnames": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Hostnames of the instances to get the license of.",
+			},
+			"instances": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "License of the selected instances.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"instance_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the instance.",
+						},
+						"instance_address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Management address of the instance.",
+						},
+						"hostname": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Hostname of the instance.",
+						},
+						"license_status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "License status of the instance, such as `Active`. Not set when the instance has never been licensed.",
+						},
+						"license_sub_status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "License sub status of the instance, such as `ACK_VERIFICATION_COMPLETE`.",
+						},
+						"expiry_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Expiry date of the license.",
+						},
+						"days_until_expiry": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of days until the license expires, negative when the license has expired.",
+						},
+						"subscription_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Subscription type of the license, such as `paid`.",
+						},
+						"subscription_sub_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Subscription sub type of the license, such as `internal`.",
+						},
+						"token_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the JWT token the instance is licensed with.",
+						},
+						"token_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Nickname of the JWT token the instance is licensed with.",
+						},
+						"features": licenseFeaturesSchema("Features enabled by the license."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CMInstanceLicenseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMInstanceLicenseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMInstanceLicenseDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	var addresses, hostnames []string
+	resp.Diagnostics.Append(data.InstanceAddresses.ElementsAs(ctx, &addresses, false)...)
+	resp.Diagnostics.Append(data.Hostnames.ElementsAs(ctx, &hostnames, false)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	inventory, err := d.client.GetDeviceInventory()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Device inventory, got error: %s", err))
+		return
+	}
+	found := make(map[string]bool)
+	data.Instances = []CMInstanceLicenseSourceModel{}
+	licenseReq := &bigipnextsdk.LicenseDeactivaeReq{}
+	for _, device := range inventory.Embedded.Devices {
+		selected := len(addresses) == 0 && len(hostnames) == 0
+		if slices.Contains(addresses, device.Address) {
+			found[device.Address] = true
+			selected = true
+		}
+		if slices.Contains(hostnames, device.Hostname) {
+			found[device.Hostname] = true
+			selected = true
+		}
+		if !selected {
+			continue
+		}
+		licenseReq.DigitalAssetIds = append(licenseReq.DigitalAssetIds, device.Id)
+		data.Instances = append(data.Instances, CMInstanceLicenseSourceModel{
+			InstanceId:      types.StringValue(device.Id),
+			InstanceAddress: types.StringValue(device.Address),
+			Hostname:        types.StringValue(device.Hostname),
+		})
+	}
+	var missing []string
+	for _, name := range append(addresses, hostnames...) {
+		if !found[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		resp.Diagnostics.AddError("Instance Not Found", fmt.Sprintf("Instances %s were not found on Central Manager", strings.Join(missing, ", ")))
+		return
+	}
+
+	licenseInfo := make(map[string]deviceLicenseInfo)
+	if len(licenseReq.DigitalAssetIds) > 0 {
+		respData, err := d.client.PostLicenseInfo(licenseReq)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to get License Info, got error: %s", err))
+			return
+		}
+		// the SDK returns the license info as map, convert it to the typed license info
+		respBytes, err := json.Marshal(respData)
+		if err == nil {
+			err = json.Unmarshal(respBytes, &licenseInfo)
+		}
+		if err != nil { // coverage-ignore
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse License Info, got error: %s", err))
+			return
+		}
+	}
+	for i, instance := range data.Instances {
+		instanceLicense(ctx, licenseInfo, &data.Instances[i])
+		tflog.Info(ctx, fmt.Sprintf("License of instance %s: %s", instance.InstanceAddress.ValueString(), data.Instances[i].LicenseStatus.ValueString()))
+	}
+	data.ID = types.StringValue("cm_instance_license")
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// instanceLicense sets the license of the instance from the license info, the license attributes are not set when
+// the instance has never been licensed
+func instanceLicense(ctx context.Context, licenseInfo map[string]deviceLicenseInfo, data *CMInstanceLicenseSourceModel) {
+	data.LicenseStatus = types.StringNull()
+	data.LicenseSubStatus = types.StringNull()
+	data.ExpiryDate = types.StringNull()
+	data.DaysUntilExpiry = types.Int64Null()
+	data.SubscriptionType = types.StringNull()
+	data.SubscriptionSubType = types.StringNull()
+	data.TokenId = types.StringNull()
+	data.TokenName = types.StringNull()
+	data.Features = []LicenseFeatureModel{}
+	info, ok := licenseInfo[data.InstanceId.ValueString()]
+	if !ok || info.DeviceLicenseStatus.LicenseStatus == "" {
+		return
+	}
+	status := info.DeviceLicenseStatus
+	data.LicenseStatus = types.StringValue(status.LicenseStatus)
+	data.LicenseSubStatus = types.StringValue(status.LicenseSubStatus)
+	data.ExpiryDate = types.StringValue(status.ExpiryDate)
+	if days, ok := licenseTokenDaysUntilExpiry(status.ExpiryDate); ok {
+		data.DaysUntilExpiry = types.Int64Value(days)
+	}
+	data.SubscriptionType = types.StringValue(status.SubscriptionType)
+	data.SubscriptionSubType = types.StringValue(status.SubscriptionSubType)
+	data.TokenId = types.StringValue(status.LicenseToken.TokenId)
+	data.TokenName = types.StringValue(status.LicenseToken.TokenName)
+	var features []licenseFeature
+	if status.EnabledFeatures != "" {
+		if err := json.Unmarshal([]byte(status.EnabledFeatures), &features); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Unable to parse enabled features of instance %s: %s", data.InstanceId.ValueString(), err))
+		}
+	}
+	data.Features = licenseFeaturesToModel(features)
+}
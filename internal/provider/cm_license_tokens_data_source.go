@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMLicenseTokensDataSource{}
+)
+
+func NewCMLicenseTokensDataSource() datasource.DataSource {
+	return &CMLicenseTokensDataSource{}
+}
+
+// CMLicenseTokensDataSource defines the data source implementation.
+type CMLicenseTokensDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMLicenseTokensDataSourceModel describes the data source data model.
+type CMLicenseTokensDataSourceModel struct {
+	ID     types.String                `tfsdk:"id"`
+	Tokens []CMLicenseTokenSourceModel `tfsdk:"tokens"`
+}
+
+type CMLicenseTokenSourceModel struct {
+	Id                 types.String          `tfsdk:"id"`
+	TokenName          types.String          `tfsdk:"token_name"`
+	OrderType          types.String          `tfsdk:"order_type"`
+	OrderSubType       types.String          `tfsdk:"order_sub_type"`
+	SubscriptionExpiry types.String          `tfsdk:"subscription_expiry"`
+	DaysUntilExpiry    types.Int64           `tfsdk:"days_until_expiry"`
+	Entitlements       []LicenseFeatureModel `tfsdk:"entitlements"`
+}
+
+type LicenseFeatureModel struct {
+	FeatureFlag types.String `tfsdk:"feature_flag"`
+	Used        types.Int64  `tfsdk:"used"`
+	Remaining   types.Int64  `tfsdk:"remaining"`
+	Permitted   types.Int64  `tfsdk:"permitted"`
+	Unlimited   types.Bool   `tfsdk:"unlimited"`
+}
+
+type licenseToken struct {
+	Id                 string `json:"id"`
+	NickName           string `json:"nickName"`
+	Entitlement        string `json:"entitlement"`
+	OrderType          string `json:"orderType"`
+	OrderSubType       string `json:"orderSubType"`
+	SubscriptionExpiry string `json:"subscriptionExpiry"`
+}
+
+type licenseTokenEntitlement struct {
+	EntitledFeatures []licenseFeature `json:"entitledFeatures"`
+}
+
+type licenseFeature struct {
+	FeatureFlag      string `json:"featureFlag"`
+	FeaturePermitted int64  `json:"featurePermitted"`
+	FeatureRemain    int64  `json:"featureRemain"`
+	FeatureUnlimited bool   `json:"featureUnlimited"`
+	FeatureUsed      int64  `json:"featureUsed"`
+}
+
+func (d *CMLicenseTokensDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_license_tokens"
+}
+
+func (d *CMLicenseTokensDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get information about the JWT license tokens added on BIG-IP Next Central Manager.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this data source.",
+			},
+			"tokens": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of license tokens.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the license token.",
+						},
+						"token_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Nickname of the license token.",
+						},
+						"order_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Order type of the license token, such as `paid`.",
+						},
+						"order_sub_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Order sub type of the license token, such as `internal`.",
+						},
+						"subscription_expiry": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Expiry date of the subscription.",
+						},
+						"days_until_expiry": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Number of days until the subscription expires, negative when the subscription has expired.",
+						},
+						"entitlements": licenseFeaturesSchema("Entitled features of the license token."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CMLicenseTokensDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMLicenseTokensDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMLicenseTokensDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	respData, err := d.client.GetLicenseTokens()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read license tokens, got error: %s", err))
+		return
+	}
+	tokens, err := parseLicenseTokens(respData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse license tokens, got error: %s", err))
+		return
+	}
+	data.Tokens = []CMLicenseTokenSourceModel{}
+	for _, token := range tokens {
+		tokenModel := CMLicenseTokenSourceModel{
+			Id:                 types.StringValue(token.Id),
+			TokenName:          types.StringValue(token.NickName),
+			OrderType:          types.StringValue(token.OrderType),
+			OrderSubType:       types.StringValue(token.OrderSubType),
+			SubscriptionExpiry: types.StringValue(token.SubscriptionExpiry),
+			DaysUntilExpiry:    types.Int64Null(),
+		}
+		if days, ok := licenseTokenDaysUntilExpiry(token.SubscriptionExpiry); ok {
+			tokenModel.DaysUntilExpiry = types.Int64Value(days)
+		}
+		var entitlement licenseTokenEntitlement
+		if err := json.Unmarshal([]byte(token.Entitlement), &entitlement); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Unable to parse entitlement of license token %s: %s", token.Id, err))
+		}
+		tokenModel.Entitlements = licenseFeaturesToModel(entitlement.EntitledFeatures)
+		data.Tokens = append(data.Tokens, tokenModel)
+	}
+	data.ID = types.StringValue("cm_license_tokens")
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// licenseFeaturesSchema returns the schema of the licensed features shared by the license data sources
+func licenseFeaturesSchema(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Computed:            true,
+		MarkdownDescription: description,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"feature_flag": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "Name of the feature, such as `bigip_active_assets`.",
+				},
+				"used": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "Number of units of the feature in use.",
+				},
+				"remaining": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "Number of units of the feature remaining.",
+				},
+				"permitted": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "Number of units of the feature permitted.",
+				},
+				"unlimited": schema.BoolAttribute{
+					Computed:            true,
+					MarkdownDescription: "Whether the feature is unlimited.",
+				},
+			},
+		},
+	}
+}
+
+func licenseFeaturesToModel(features []licenseFeature) []LicenseFeatureModel {
+	featuresModel := []LicenseFeatureModel{}
+	for _, feature := range features {
+		featuresModel = append(featuresModel, LicenseFeatureModel{
+			FeatureFlag: types.StringValue(feature.FeatureFlag),
+			Used:        types.Int64Value(feature.FeatureUsed),
+			Remaining:   types.Int64Value(feature.FeatureRemain),
+			Permitted:   types.Int64Value(feature.FeaturePermitted),
+			Unlimited:   types.BoolValue(feature.FeatureUnlimited),
+		})
+	}
+	return featuresModel
+}
+
+// parseLicenseTokens parses the list of license tokens, which is either a plain list or embedded in _embedded.tokens
+func parseLicenseTokens(respData []byte) ([]licenseToken, error) {
+	var tokens []licenseToken
+	if err := json.Unmarshal(respData, &tokens); err == nil {
+		return tokens, nil
+	}
+	var embedded struct {
+		Embedded struct {
+			Tokens []licenseToken `json:"tokens"`
+		} `json:"_embedded"`
+	}
+	if err := json.Unmarshal(respData, &embedded); err != nil {
+		return nil, err
+	}
+	return embedded.Embedded.Tokens, nil
+}
+
+// licenseTokenDaysUntilExpiry returns the number of days until the subscription expiry, ok is false when the
+// expiry is not a valid date
+func licenseTokenDaysUntilExpiry(expiry string) (int64, bool) {
+	expiryTime, err := time.Parse(time.RFC3339, expiry)
+	if err != nil {
+		return 0, false
+	}
+	return int64(math.Floor(time.Until(expiryTime).Hours() / 24)), true
+}
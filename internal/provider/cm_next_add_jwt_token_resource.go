@@ -2,11 +2,15 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -17,6 +21,7 @@ import (
 var (
 	_ resource.Resource                = &CMNextJwtTokenResource{}
 	_ resource.ResourceWithImportState = &CMNextJwtTokenResource{}
+	_ resource.ResourceWithModifyPlan  = &CMNextJwtTokenResource{}
 )
 
 func NewCMNextJwtTokenResource() resource.Resource {
@@ -31,7 +36,10 @@ type CMNextJwtTokenResourceModel struct {
 	TokenName          types.String `tfsdk:"token_name"`
 	JwtToken           types.String `tfsdk:"jwt_token"`
 	OrderType          types.String `tfsdk:"order_type"`
+	OrderSubType       types.String `tfsdk:"order_sub_type"`
 	SubscriptionExpiry types.String `tfsdk:"subscription_expiry"`
+	DaysUntilExpiry    types.Int64  `tfsdk:"days_until_expiry"`
+	ExpiryWarningDays  types.Int64  `tfsdk:"expiry_warning_days"`
 	Id                 types.String `tfsdk:"id"`
 }
 
@@ -41,7 +49,9 @@ func (r *CMNextJwtTokenResource) Metadata(ctx context.Context, req resource.Meta
 
 func (r *CMNextJwtTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used for add/copy JWT Token on Central Manager",
+		MarkdownDescription: "Resource used for add/copy JWT Token on Central Manager.\n\n" +
+			"The JWT token is verified with Central Manager when it is planned. When `jwt_token` or `token_name` changes, " +
+			"for example when the yearly subscription is renewed, the new token is added in place and the existing token is removed once the new token is added.",
 		Attributes: map[string]schema.Attribute{
 			"token_name": schema.StringAttribute{
 				Required:            true,
@@ -52,13 +62,27 @@ func (r *CMNextJwtTokenResource) Schema(ctx context.Context, req resource.Schema
 				Sensitive:           true,
 				MarkdownDescription: "JWT token to be added on Central Manager",
 			},
+			"expiry_warning_days": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(30),
+				MarkdownDescription: "Number of days before the subscription expiry from which a warning is reported on every plan and apply, the default value is `30`.",
+			},
 			"order_type": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "JWT token to be added on Central Manager",
+				MarkdownDescription: "Order type of the JWT token, such as `paid`.",
+			},
+			"order_sub_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Order sub type of the JWT token, such as `internal`.",
 			},
 			"subscription_expiry": schema.StringAttribute{
 				Computed:            true,
-				MarkdownDescription: "JWT token to be added on Central Manager",
+				MarkdownDescription: "Expiry date of the subscription of the JWT token.",
+			},
+			"days_until_expiry": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Number of days until the subscription expires, negative when the subscription has expired.",
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -75,6 +99,26 @@ func (r *CMNextJwtTokenResource) Configure(ctx context.Context, req resource.Con
 	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
 }
 
+// ModifyPlan verifies new or changed JWT tokens with Central Manager
+func (r *CMNextJwtTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var planCfg CMNextJwtTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planCfg)...)
+	if resp.Diagnostics.HasError() || planCfg.JwtToken.IsUnknown() { // coverage-ignore
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var stateCfg CMNextJwtTokenResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+		if resp.Diagnostics.HasError() || stateCfg.JwtToken.Equal(planCfg.JwtToken) {
+			return
+		}
+	}
+	resp.Diagnostics.Append(r.verifyToken(ctx, &planCfg)...)
+}
+
 func (r *CMNextJwtTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var resCfg *CMNextJwtTokenResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
@@ -86,7 +130,7 @@ func (r *CMNextJwtTokenResource) Create(ctx context.Context, req resource.Create
 	providerConfig := getCMNextJwtTokenConfig(ctx, resCfg)
 	respData, err := r.client.PostLicenseToken(providerConfig)
 	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError("Failed to Create Jwt token", fmt.Sprintf(", got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Create Jwt token, got error: %s", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] JWT token ID :%+v\n", string(respData)))
@@ -95,12 +139,13 @@ func (r *CMNextJwtTokenResource) Create(ctx context.Context, req resource.Create
 
 	tokenInfo, err := r.client.GetLicenseToken(string(respData))
 	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError("Failed to Get JWT Token Info", fmt.Sprintf(", got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Get JWT Token Info, got error: %s", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("JWT Token Info : %+v", tokenInfo))
 
 	r.NextJwtTokenResourceModeltoState(ctx, tokenInfo, resCfg)
+	resp.Diagnostics.Append(licenseTokenExpiryDiagnostics(resCfg)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
 }
 
@@ -114,6 +159,11 @@ func (r *CMNextJwtTokenResource) Read(ctx context.Context, req resource.ReadRequ
 	tflog.Info(ctx, fmt.Sprintf("JWT Token ID : %+v", id))
 
 	tokenInfo, err := r.client.GetLicenseToken(id)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Info(ctx, fmt.Sprintf("JWT Token %s not found, removing from state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Get JWT Token Info, got error: %s", err))
 		return
@@ -121,6 +171,7 @@ func (r *CMNextJwtTokenResource) Read(ctx context.Context, req resource.ReadRequ
 	tflog.Info(ctx, fmt.Sprintf("JWT Token Info : %+v", tokenInfo))
 
 	r.NextJwtTokenResourceModeltoState(ctx, tokenInfo, stateCfg)
+	resp.Diagnostics.Append(licenseTokenExpiryDiagnostics(stateCfg)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
 }
@@ -130,21 +181,95 @@ func (r *CMNextJwtTokenResource) NextJwtTokenResourceModeltoState(ctx context.Co
 	data.OrderType = types.StringValue(respData.(map[string]interface{})["orderType"].(string))
 	data.SubscriptionExpiry = types.StringValue(respData.(map[string]interface{})["subscriptionExpiry"].(string))
 	data.TokenName = types.StringValue(respData.(map[string]interface{})["nickName"].(string))
+	orderSubType, _ := respData.(map[string]interface{})["orderSubType"].(string)
+	data.OrderSubType = types.StringValue(orderSubType)
+	data.DaysUntilExpiry = types.Int64Null()
+	if days, ok := licenseTokenDaysUntilExpiry(data.SubscriptionExpiry.ValueString()); ok {
+		data.DaysUntilExpiry = types.Int64Value(days)
+	}
 }
 
 func (r *CMNextJwtTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var resCfg *CMNextJwtTokenResourceModel
+	var resCfg, stateCfg *CMNextJwtTokenResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
-
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
 	if resp.Diagnostics.HasError() { // coverage-ignore
 		return
 	}
-	tflog.Info(ctx, "[UPDATE] Updating JWT Tokens Not Supported!!!!")
-	resCfg.OrderType = types.StringValue(resCfg.OrderType.ValueString())
-	resCfg.SubscriptionExpiry = types.StringValue(resCfg.SubscriptionExpiry.ValueString())
+	tflog.Info(ctx, fmt.Sprintf("[UPDATE] CMNextJwtTokenResource:%+v\n", resCfg.TokenName.ValueString()))
+
+	id := stateCfg.Id.ValueString()
+	// jwt_token is not known after import, the imported token is kept unless the nickname changes
+	jwtChanged := !stateCfg.JwtToken.IsNull() && !resCfg.JwtToken.Equal(stateCfg.JwtToken)
+	if jwtChanged || !resCfg.TokenName.Equal(stateCfg.TokenName) {
+		// the new token is verified and added before the existing token is removed, so a failure leaves the
+		// existing token in place
+		resp.Diagnostics.Append(r.verifyToken(ctx, resCfg)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		respData, err := r.client.PostLicenseToken(getCMNextJwtTokenConfig(ctx, resCfg))
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Replace Jwt token, adding the new token failed and JWT token %s is kept, got error: %s", id, err))
+			return
+		}
+		id = string(respData)
+		resCfg.Id = types.StringValue(id)
+		if err := r.client.DeleteLicenseToken(stateCfg.Id.ValueString()); err != nil { // coverage-ignore
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to Delete JWT Token %s replaced by %s, got error: %s", stateCfg.Id.ValueString(), id, err))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("[UPDATE] Replaced JWT token %s with %s", stateCfg.Id.ValueString(), id))
+	}
+	resCfg.Id = types.StringValue(id)
+
+	tokenInfo, err := r.client.GetLicenseToken(id)
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Get JWT Token Info, got error: %s", err))
+		return
+	}
+	r.NextJwtTokenResourceModeltoState(ctx, tokenInfo, resCfg)
+	resp.Diagnostics.Append(licenseTokenExpiryDiagnostics(resCfg)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
 }
 
+// verifyToken verifies the JWT token with Central Manager and fails when the subscription has expired
+func (r *CMNextJwtTokenResource) verifyToken(ctx context.Context, data *CMNextJwtTokenResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	respData, err := r.client.PostLicenseTokenVerify(getCMNextJwtTokenConfig(ctx, data))
+	if err != nil {
+		diags.AddAttributeError(path.Root("jwt_token"), "Invalid JWT Token", fmt.Sprintf("Failed to verify JWT token %s, got error: %s", data.TokenName.ValueString(), err))
+		return diags
+	}
+	verifyResp := make(map[string]interface{})
+	if err := json.Unmarshal(respData, &verifyResp); err != nil { // coverage-ignore
+		tflog.Warn(ctx, fmt.Sprintf("Unable to parse JWT token verification response: %s", err))
+		return diags
+	}
+	expiry, _ := verifyResp["subscriptionExpiry"].(string)
+	if days, ok := licenseTokenDaysUntilExpiry(expiry); ok && days < 0 {
+		diags.AddAttributeError(path.Root("jwt_token"), "JWT Token Expired", fmt.Sprintf("The subscription of JWT token %s expired on %s", data.TokenName.ValueString(), expiry))
+	}
+	return diags
+}
+
+// licenseTokenExpiryDiagnostics warns when the subscription of the token expires within expiry_warning_days
+func licenseTokenExpiryDiagnostics(data *CMNextJwtTokenResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if data.DaysUntilExpiry.IsNull() {
+		return diags
+	}
+	days := data.DaysUntilExpiry.ValueInt64()
+	switch {
+	case days < 0:
+		diags.AddWarning("License Token Expired", fmt.Sprintf("The subscription of JWT token %s expired on %s, renew the subscription and update jwt_token.", data.TokenName.ValueString(), data.SubscriptionExpiry.ValueString()))
+	case days <= data.ExpiryWarningDays.ValueInt64():
+		diags.AddWarning("License Token Expiring Soon", fmt.Sprintf("The subscription of JWT token %s expires in %d days on %s, renew the subscription and update jwt_token.", data.TokenName.ValueString(), days, data.SubscriptionExpiry.ValueString()))
+	}
+	return diags
+}
+
 func (r *CMNextJwtTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var stateCfg *CMNextJwtTokenResourceModel
 	if resp.Diagnostics.HasError() { // coverage-ignore
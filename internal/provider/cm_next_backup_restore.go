@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -21,8 +23,9 @@ import (
 )
 
 var (
-	_ resource.Resource                = &NextCMDeviceBackupRestoreResource{}
-	_ resource.ResourceWithImportState = &NextCMDeviceBackupRestoreResource{}
+	_ resource.Resource                   = &NextCMDeviceBackupRestoreResource{}
+	_ resource.ResourceWithImportState    = &NextCMDeviceBackupRestoreResource{}
+	_ resource.ResourceWithValidateConfig = &NextCMDeviceBackupRestoreResource{}
 )
 
 func NewNextCMDeviceBackupRestoreResource() resource.Resource {
@@ -34,15 +37,19 @@ type NextCMDeviceBackupRestoreResource struct {
 }
 
 type NextCMDeviceBackupRestoreResourceModel struct {
-	FileName       types.String `tfsdk:"file_name"`
-	Password       types.String `tfsdk:"backup_password"`
-	DeviceIp       types.String `tfsdk:"device_ip"`
-	DeviceHostname types.String `tfsdk:"device_hostname"`
-	Operation      types.String `tfsdk:"operation"`
-	Id             types.String `tfsdk:"instance_id"`
-	Backup         types.String `tfsdk:"backup_date"`
-	Restore        types.String `tfsdk:"restore_date"`
-	Timeout        types.Int64  `tfsdk:"timeout"`
+	FileName        types.String `tfsdk:"file_name"`
+	Password        types.String `tfsdk:"backup_password"`
+	DeviceIp        types.String `tfsdk:"device_ip"`
+	DeviceHostname  types.String `tfsdk:"device_hostname"`
+	Operation       types.String `tfsdk:"operation"`
+	Id              types.String `tfsdk:"instance_id"`
+	Backup          types.String `tfsdk:"backup_date"`
+	Restore         types.String `tfsdk:"restore_date"`
+	Timeout         types.Int64  `tfsdk:"timeout"`
+	UploadFilePath  types.String `tfsdk:"upload_file_path"`
+	Archive         types.Object `tfsdk:"archive"`
+	ArchiveLocation types.String `tfsdk:"archive_location"`
+	ArchiveSha256   types.String `tfsdk:"archive_sha256"`
 }
 
 func (r *NextCMDeviceBackupRestoreResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -119,10 +126,39 @@ func (r *NextCMDeviceBackupRestoreResource) Schema(ctx context.Context, req reso
 				Computed:            true,
 				Default:             int64default.StaticInt64(360),
 			},
+			"upload_file_path": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Path of a backup archive kept outside of BIG-IP Next CM, such as an archive downloaded with `archive`." +
+					" The archive is uploaded to CM as `file_name` and validated with `backup_password` before it is restored. Only valid for `restore` operation.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"archive": backupArchiveSchema(),
+			"archive_location": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Location the backup file was archived to, a local path or an `s3://` URL. Only set for `backup` operation.",
+			},
+			"archive_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 checksum of the archived backup file. Only set for `backup` operation.",
+			},
 		},
 	}
 }
 
+func (r *NextCMDeviceBackupRestoreResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NextCMDeviceBackupRestoreResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.UploadFilePath.IsNull() || data.UploadFilePath.IsUnknown() {
+		return
+	}
+	if !data.Operation.IsUnknown() && data.Operation.ValueString() != "restore" {
+		resp.Diagnostics.AddAttributeError(path.Root("upload_file_path"), "Invalid Attribute Combination",
+			"upload_file_path can only be used with restore operation.")
+	}
+}
+
 func (r *NextCMDeviceBackupRestoreResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
 }
@@ -157,6 +193,14 @@ func (r *NextCMDeviceBackupRestoreResource) Create(ctx context.Context, req reso
 	if resp.Diagnostics.HasError() { // coverage-ignore
 		return
 	}
+	// the backup file is checked at apply time, it can be created by another resource of the same apply
+	if !data.UploadFilePath.IsNull() && data.Operation.ValueString() == "restore" {
+		if _, err := os.Stat(data.UploadFilePath.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("upload_file_path"), "Backup File Not Found",
+				fmt.Sprintf("Unable to read backup file to upload, got error: %s", err))
+			return
+		}
+	}
 	deviceId, err := r.GetDeviceId(data)
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to obtain device id, got error: %s", err))
@@ -166,25 +210,43 @@ func (r *NextCMDeviceBackupRestoreResource) Create(ctx context.Context, req reso
 
 	if data.Operation.ValueString() == "backup" {
 		mutex.Lock()
+		unlock := lockInstanceBackup(*deviceId)
 		respData, err := r.client.BackupTenant(deviceId, config, int(data.Timeout.ValueInt64()))
+		unlock()
 		if err != nil { // coverage-ignore
+			mutex.Unlock()
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create config backup, got error: %s", err))
 			return
 		}
 		tflog.Info(ctx, fmt.Sprintf("Backup operation response:%+v", respData))
 		r.backupResourceModelToState(ctx, respData, data)
 		mutex.Unlock()
+		if err = r.archiveBackup(ctx, data); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to archive config backup, got error: %s", err))
+		}
 	}
 	if data.Operation.ValueString() == "restore" {
+		if !data.UploadFilePath.IsNull() {
+			tflog.Info(ctx, fmt.Sprintf("Uploading backup file %s as %s", data.UploadFilePath.ValueString(), data.FileName.ValueString()))
+			if _, err := importNextBackupFile(r.client, data.UploadFilePath.ValueString(), data.FileName.ValueString(), data.Password.ValueString()); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to upload config backup, got error: %s", err))
+				return
+			}
+		}
 		mutex.Lock()
+		unlock := lockInstanceBackup(*deviceId)
 		respData, err := r.client.RestoreTenant(deviceId, config, int(data.Timeout.ValueInt64()))
+		unlock()
 		if err != nil { // coverage-ignore
+			mutex.Unlock()
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to restore config backup, got error: %s", err))
 			return
 		}
 		tflog.Info(ctx, fmt.Sprintf("Restore operation response:%+v", respData))
 		r.backupResourceModelToState(ctx, respData, data)
 		mutex.Unlock()
+		data.ArchiveLocation = types.StringNull()
+		data.ArchiveSha256 = types.StringNull()
 	}
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -213,25 +275,36 @@ func (r *NextCMDeviceBackupRestoreResource) Update(ctx context.Context, req reso
 			return
 		}
 		mutex.Lock()
+		unlock := lockInstanceBackup(*deviceId)
 		respData, err := r.client.BackupTenant(deviceId, config, int(data.Timeout.ValueInt64()))
+		unlock()
 		if err != nil {
+			mutex.Unlock()
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to create config backup, got error: %s", err))
 			return
 		}
 		tflog.Info(ctx, fmt.Sprintf("Backup operation response:%+v", respData))
 		r.backupResourceModelToState(ctx, respData, data)
 		mutex.Unlock()
+		if err = r.archiveBackup(ctx, data); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to archive config backup, got error: %s", err))
+		}
 	}
 	if data.Operation.ValueString() == "restore" {
 		mutex.Lock()
+		unlock := lockInstanceBackup(*deviceId)
 		respData, err := r.client.RestoreTenant(deviceId, config, int(data.Timeout.ValueInt64()))
+		unlock()
 		if err != nil {
+			mutex.Unlock()
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to restore config backup, got error: %s", err))
 			return
 		}
 		tflog.Info(ctx, fmt.Sprintf("Restore operation response:%+v", respData))
 		r.backupResourceModelToState(ctx, respData, data)
 		mutex.Unlock()
+		data.ArchiveLocation = types.StringNull()
+		data.ArchiveSha256 = types.StringNull()
 	}
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -330,6 +403,27 @@ func (r *NextCMDeviceBackupRestoreResource) backupResourceModelToState(ctx conte
 	}
 }
 
+// archiveBackup copies the instance backup file to the configured archive destination and sets the archive attributes
+func (r *NextCMDeviceBackupRestoreResource) archiveBackup(ctx context.Context, data *NextCMDeviceBackupRestoreResourceModel) error {
+	data.ArchiveLocation = types.StringNull()
+	data.ArchiveSha256 = types.StringNull()
+	if data.Archive.IsNull() {
+		return nil
+	}
+	fileName := data.FileName.ValueString()
+	// archives of this resource share the base name of file_name, such as nightly-20240701.tar.gz for nightly.tar.gz
+	naming := backupArchiveNaming{prefix: removeExtensions(fileName), suffix: ".tar.gz"}
+	location, checksum, err := backupArchive(ctx, data.Archive, fileName, naming, "", func(w io.Writer) (int64, error) {
+		return downloadNextBackupFile(r.client, fileName, w)
+	})
+	if err != nil {
+		return err
+	}
+	data.ArchiveLocation = types.StringValue(location)
+	data.ArchiveSha256 = types.StringValue(checksum)
+	return nil
+}
+
 func removeExtensions(fileName string) string {
 	ext := filepath.Ext(fileName)
 	if ext != "" {
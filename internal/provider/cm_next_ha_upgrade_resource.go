@@ -0,0 +1,440 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource = &CMNextHAUpgradeResource{}
+)
+
+func NewCMNextHAUpgradeResource() resource.Resource {
+	return &CMNextHAUpgradeResource{}
+}
+
+type CMNextHAUpgradeResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type CMNextHAUpgradeResourceModel struct {
+	HaIP                      types.String `tfsdk:"ha_ip"`
+	ImageName                 types.String `tfsdk:"image_name"`
+	SignatureFilename         types.String `tfsdk:"signature_filename"`
+	TargetVersion             types.String `tfsdk:"target_version"`
+	HealthCheck               types.Object `tfsdk:"health_check"`
+	AbortOnHealthCheckFailure types.Bool   `tfsdk:"abort_on_health_check_failure"`
+	Timeout                   types.Int64  `tfsdk:"timeout"`
+	FailoverTimeout           types.Int64  `tfsdk:"failover_timeout"`
+	CurrentVersion            types.String `tfsdk:"current_version"`
+	ActiveInstanceId          types.String `tfsdk:"active_instance_id"`
+	StandbyInstanceId         types.String `tfsdk:"standby_instance_id"`
+	Id                        types.String `tfsdk:"id"`
+}
+
+type NextHAHealthCheck struct {
+	Paths    types.List  `tfsdk:"paths"`
+	Retries  types.Int64 `tfsdk:"retries"`
+	Interval types.Int64 `tfsdk:"interval"`
+}
+
+// defaults used when health_check is not configured
+var (
+	defaultNextHAHealthCheckPaths    = []string{"/systems"}
+	defaultNextHAHealthCheckRetries  = 10
+	defaultNextHAHealthCheckInterval = 30
+)
+
+func (r *CMNextHAUpgradeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_next_ha_upgrade"
+}
+
+func (r *CMNextHAUpgradeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used for rolling upgrade of BIG-IP Next VE HA clusters managed by the BIG-IP Central Manager.\n\n" +
+			"The standby instance is upgraded first and its health is verified, then the cluster fails over and the former active instance is upgraded and verified." +
+			" When a health check fails the upgrade is aborted before the next step, so the active instance is only touched after the standby instance is healthy on the new version." +
+			" Instances which already run `target_version` are not upgraded again, so an aborted rolling upgrade resumes where it stopped on the next apply.\n\n" +
+			"~> **NOTE** An upgrade cannot be reverted. When doing `terraform destroy` it will only remove the resource from the state",
+		Attributes: map[string]schema.Attribute{
+			"ha_ip": schema.StringAttribute{
+				MarkdownDescription: "The management IP of the BIG-IP Next HA cluster that is to be upgraded.",
+				Required:            true,
+			},
+			"image_name": schema.StringAttribute{
+				MarkdownDescription: "The name of the BIG-IP Next image file that is to be used to upgrade the HA cluster instances.",
+				Required:            true,
+			},
+			"signature_filename": schema.StringAttribute{
+				MarkdownDescription: "The name of the signature file that is to be used to verify the image file.",
+				Required:            true,
+			},
+			"target_version": schema.StringAttribute{
+				MarkdownDescription: "The BIG-IP Next version the HA cluster is upgraded to, e.g. `20.3.0-2.713.1`. When not set it is derived from `image_name`," +
+					" the upgrade is skipped when both instances already run this version.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(nextVersionRegexpFull, "must be a BIG-IP Next version like 20.3.0 or 20.3.0-2.713.1"),
+				},
+				PlanModifiers: []planmodifier.String{
+					targetVersionFromImageName(),
+				},
+			},
+			"health_check": schema.SingleNestedAttribute{
+				MarkdownDescription: "Health checks done on the HA cluster before the upgrade and on each instance after it is upgraded." +
+					" When not set the `/systems` API of the instances is checked 10 times every 30 seconds.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"paths": schema.ListAttribute{
+						MarkdownDescription: "BIG-IP Next API paths, e.g. `/systems`, that must answer with success through the Central Manager proxy for the instance to be healthy.",
+						ElementType:         types.StringType,
+						Required:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+					},
+					"retries": schema.Int64Attribute{
+						MarkdownDescription: "Number of attempts for each path before the health check fails.",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"interval": schema.Int64Attribute{
+						MarkdownDescription: "Time in seconds between attempts.",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+				},
+			},
+			"abort_on_health_check_failure": schema.BoolAttribute{
+				MarkdownDescription: "Abort the rolling upgrade when a health check fails, the default value is `true`. When set to `false` a failed health check is reported as a warning and the upgrade continues.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "The time in seconds to wait for the upgrade of each instance to complete, the default value is 3600 seconds.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(3600),
+			},
+			"failover_timeout": schema.Int64Attribute{
+				MarkdownDescription: "The time in seconds to wait for the failover to the upgraded standby instance, the default value is 300 seconds.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(300),
+			},
+			"current_version": schema.StringAttribute{
+				MarkdownDescription: "The software version of the HA cluster as reported by the Central Manager inventory.",
+				Computed:            true,
+			},
+			"active_instance_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the active instance of the HA cluster.",
+				Computed:            true,
+			},
+			"standby_instance_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the standby instance of the HA cluster.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique Identifier for the resource, ID of the HA cluster.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CMNextHAUpgradeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *CMNextHAUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *CMNextHAUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	r.rollingUpgrade(ctx, resCfg, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMNextHAUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMNextHAUpgradeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	cluster, err := getNextInstance(r.client, stateCfg.HaIP.ValueString())
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			tflog.Warn(ctx, fmt.Sprintf("HA cluster %s not found, removing resource from state", stateCfg.HaIP.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read HA cluster, got error: %s", err))
+		return
+	}
+	haInfo, err := getDeviceHA(r.client, cluster.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read HA configuration, got error: %s", err))
+		return
+	}
+	stateCfg.Id = types.StringValue(cluster.Id)
+	stateCfg.CurrentVersion = types.StringValue(cluster.Version)
+	stateCfg.ActiveInstanceId = types.StringValue(haInfo.ActiveInstanceId)
+	stateCfg.StandbyInstanceId = types.StringValue(haInfo.StandbyInstanceId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *CMNextHAUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg *CMNextHAUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	r.rollingUpgrade(ctx, resCfg, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMNextHAUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *CMNextHAUpgradeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	stateCfg.Id = types.StringValue("")
+}
+
+// rollingUpgrade upgrades the standby instance, fails over to it and upgrades the former active instance,
+// the health checks run before the upgrade, after each instance upgrade and after the failover
+func (r *CMNextHAUpgradeResource) rollingUpgrade(ctx context.Context, resCfg *CMNextHAUpgradeResourceModel, diags *diag.Diagnostics) {
+	healthCheck := r.getHealthCheck(ctx, resCfg, diags)
+	if diags.HasError() { // coverage-ignore
+		return
+	}
+	abort := resCfg.AbortOnHealthCheckFailure.ValueBool()
+
+	cluster, err := getNextInstance(r.client, resCfg.HaIP.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to Read HA cluster, got error: %s", err))
+		return
+	}
+	if !strings.EqualFold(cluster.Mode, "HA") {
+		diags.AddError("pre-upgrade check failed", fmt.Sprintf("NEXT instance %s is not an HA cluster, mode: %s", resCfg.HaIP.ValueString(), cluster.Mode))
+		return
+	}
+	resCfg.Id = types.StringValue(cluster.Id)
+
+	haInfo, err := getDeviceHA(r.client, cluster.Id)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to Read HA configuration, got error: %s", err))
+		return
+	}
+	activeId, standbyId := haInfo.ActiveInstanceId, haInfo.StandbyInstanceId
+
+	// each instance is checked, a rolling upgrade which stopped after the standby upgrade or the failover
+	// resumes with the instance which still runs the former version
+	activeVersion, err := r.instanceVersion(activeId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to Read version of instance %s, got error: %s", activeId, err))
+		return
+	}
+	standbyVersion, err := r.instanceVersion(standbyId)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to Read version of instance %s, got error: %s", standbyId, err))
+		return
+	}
+	activeUpgraded := nextVersionMatches(activeVersion, resCfg.TargetVersion.ValueString())
+	standbyUpgraded := nextVersionMatches(standbyVersion, resCfg.TargetVersion.ValueString())
+	if activeUpgraded && standbyUpgraded {
+		tflog.Info(ctx, fmt.Sprintf("HA cluster %v is already on version %v, skipping upgrade", resCfg.HaIP.ValueString(), cluster.Version))
+		r.setHAUpgradeState(resCfg, cluster.Version, haInfo)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("[ROLLING UPGRADE] HA cluster %v: active %v on %v, standby %v on %v", cluster.Id, activeId, activeVersion, standbyId, standbyVersion))
+	if err := r.checkHealth(ctx, cluster.Id, healthCheck); err != nil {
+		diags.AddError("pre-upgrade check failed", fmt.Sprintf("HA cluster %s is not healthy, upgrade not started: %s", resCfg.HaIP.ValueString(), err))
+		return
+	}
+
+	// upgrade standby
+	if standbyUpgraded {
+		tflog.Info(ctx, fmt.Sprintf("[ROLLING UPGRADE] standby instance %v is already on version %v, skipping its upgrade", standbyId, standbyVersion))
+	} else {
+		if err := r.upgradeHANode(ctx, standbyId, resCfg); err != nil {
+			diags.AddError("upgrade process failed", fmt.Sprintf("upgrade of standby instance %s failed, active instance %s was not changed: %s", standbyId, activeId, err))
+			return
+		}
+		if err := r.checkHealth(ctx, standbyId, healthCheck); err != nil {
+			if abort {
+				diags.AddError("rolling upgrade aborted", fmt.Sprintf("standby instance %s is not healthy after the upgrade, active instance %s was not changed: %s", standbyId, activeId, err))
+				return
+			}
+			diags.AddWarning("health check failed", fmt.Sprintf("standby instance %s is not healthy after the upgrade: %s", standbyId, err))
+		}
+	}
+
+	if activeUpgraded {
+		tflog.Info(ctx, fmt.Sprintf("[ROLLING UPGRADE] active instance %v is already on version %v, skipping the failover and its upgrade", activeId, activeVersion))
+	} else {
+		// fail over to the upgraded standby
+		haInfo, err = failoverDeviceHA(r.client, cluster.Id, int(resCfg.FailoverTimeout.ValueInt64()))
+		if err != nil {
+			diags.AddError("rolling upgrade aborted", fmt.Sprintf("failover to upgraded instance %s failed, instance %s was not upgraded: %s", standbyId, activeId, err))
+			return
+		}
+		if err := r.checkHealth(ctx, cluster.Id, healthCheck); err != nil {
+			if abort {
+				diags.AddError("rolling upgrade aborted", fmt.Sprintf("HA cluster is not healthy after the failover to instance %s, instance %s was not upgraded: %s", standbyId, activeId, err))
+				return
+			}
+			diags.AddWarning("health check failed", fmt.Sprintf("HA cluster is not healthy after the failover to instance %s: %s", standbyId, err))
+		}
+
+		// upgrade former active, which is the standby now
+		if err := r.upgradeHANode(ctx, activeId, resCfg); err != nil {
+			diags.AddError("upgrade process failed", fmt.Sprintf("upgrade of instance %s failed, instance %s is active on the new version: %s", activeId, standbyId, err))
+			return
+		}
+		if err := r.checkHealth(ctx, activeId, healthCheck); err != nil {
+			if abort {
+				diags.AddError("rolling upgrade aborted", fmt.Sprintf("instance %s is not healthy after the upgrade: %s", activeId, err))
+				return
+			}
+			diags.AddWarning("health check failed", fmt.Sprintf("instance %s is not healthy after the upgrade: %s", activeId, err))
+		}
+	}
+
+	cluster, err = getNextInstance(r.client, resCfg.HaIP.ValueString())
+	if err != nil { // coverage-ignore
+		diags.AddError("Client Error", fmt.Sprintf("Failed to Read HA cluster, got error: %s", err))
+		return
+	}
+	r.setHAUpgradeState(resCfg, cluster.Version, haInfo)
+}
+
+func (r *CMNextHAUpgradeResource) setHAUpgradeState(resCfg *CMNextHAUpgradeResourceModel, version string, haInfo *bigipnextsdk.HATaskResp) {
+	resCfg.CurrentVersion = types.StringValue(version)
+	resCfg.ActiveInstanceId = types.StringValue(haInfo.ActiveInstanceId)
+	resCfg.StandbyInstanceId = types.StringValue(haInfo.StandbyInstanceId)
+}
+
+// instanceVersion returns the software version of an instance of the HA cluster from the CM inventory
+func (r *CMNextHAUpgradeResource) instanceVersion(instanceId string) (string, error) {
+	instanceInfo, err := r.client.GetDeviceInfoByID(instanceId)
+	if err != nil {
+		return "", err
+	}
+	instanceMap, _ := instanceInfo.(map[string]interface{})
+	version, ok := instanceMap["version"].(string)
+	if !ok {
+		return "", fmt.Errorf("version not found in inventory of instance %s: %+v", instanceId, instanceInfo)
+	}
+	return version, nil
+}
+
+// upgradeHANode upgrades a single instance of the HA cluster, untrusted certificate prompts
+// of the upgrade task are accepted by WaitForNextInstanceUpgrade
+func (r *CMNextHAUpgradeResource) upgradeHANode(ctx context.Context, instanceId string, resCfg *CMNextHAUpgradeResourceModel) error {
+	imageName, signatureName, err := r.client.GetImageAndSignatureName(instanceId, resCfg.ImageName.ValueString(), resCfg.SignatureFilename.ValueString())
+	if err != nil {
+		return err
+	}
+	upgradeTaskId, err := r.client.UpgradeVE(instanceId, imageName, signatureName)
+	if err != nil {
+		return err
+	}
+	tflog.Info(ctx, fmt.Sprintf("[ROLLING UPGRADE] instance %v upgrade task id: %v", instanceId, upgradeTaskId))
+	status, details, err := r.client.WaitForNextInstanceUpgrade(upgradeTaskId, resCfg.Timeout.ValueInt64())
+	if err != nil { // coverage-ignore
+		return err
+	}
+	if status == "failed" {
+		return fmt.Errorf("upgrade task failed: %s", details)
+	}
+	if status != "completed" { // coverage-ignore
+		return fmt.Errorf("upgrade not complete within timeout, status: %s %s", status, details)
+	}
+	return nil
+}
+
+// checkHealth calls each health check path of the instance until it succeeds or the retries are used up
+func (r *CMNextHAUpgradeResource) checkHealth(ctx context.Context, instanceId string, healthCheck *nextHAHealthCheck) error {
+	for _, apiPath := range healthCheck.paths {
+		var err error
+		for i := 0; i < healthCheck.retries; i++ {
+			err = checkNextInstanceHealth(r.client, instanceId, apiPath)
+			if err == nil {
+				break
+			}
+			tflog.Warn(ctx, fmt.Sprintf("[HEALTH CHECK] instance %v path %v attempt %d failed: %v", instanceId, apiPath, i+1, err))
+			if i < healthCheck.retries-1 {
+				time.Sleep(time.Duration(healthCheck.interval) * time.Second)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("health check %s failed: %s", apiPath, err)
+		}
+	}
+	return nil
+}
+
+type nextHAHealthCheck struct {
+	paths    []string
+	retries  int
+	interval int
+}
+
+func (r *CMNextHAUpgradeResource) getHealthCheck(ctx context.Context, resCfg *CMNextHAUpgradeResourceModel, diags *diag.Diagnostics) *nextHAHealthCheck {
+	healthCheck := &nextHAHealthCheck{
+		paths:    defaultNextHAHealthCheckPaths,
+		retries:  defaultNextHAHealthCheckRetries,
+		interval: defaultNextHAHealthCheckInterval,
+	}
+	if resCfg.HealthCheck.IsNull() || resCfg.HealthCheck.IsUnknown() {
+		return healthCheck
+	}
+	var healthCheckModel NextHAHealthCheck
+	diags.Append(resCfg.HealthCheck.As(ctx, &healthCheckModel, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() { // coverage-ignore
+		return nil
+	}
+	var paths []string
+	diags.Append(healthCheckModel.Paths.ElementsAs(ctx, &paths, false)...)
+	healthCheck.paths = paths
+	healthCheck.retries = int(healthCheckModel.Retries.ValueInt64())
+	healthCheck.interval = int(healthCheckModel.Interval.ValueInt64())
+	return healthCheck
+}
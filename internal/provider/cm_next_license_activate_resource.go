@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,6 +19,7 @@ import (
 var (
 	_ resource.Resource                = &CMNextLicenseActivateResource{}
 	_ resource.ResourceWithImportState = &CMNextLicenseActivateResource{}
+	_ resource.ResourceWithModifyPlan  = &CMNextLicenseActivateResource{}
 )
 
 func NewCMNextLicenseActivateResource() resource.Resource {
@@ -34,8 +36,12 @@ type CMNextLicenseActivateResourceModel struct {
 }
 
 type InstanceActivateModel struct {
-	InstanceAddress types.String `tfsdk:"instance_address"`
-	JwtId           types.String `tfsdk:"jwt_id"`
+	InstanceAddress  types.String `tfsdk:"instance_address"`
+	JwtId            types.String `tfsdk:"jwt_id"`
+	InstanceId       types.String `tfsdk:"instance_id"`
+	LicenseStatus    types.String `tfsdk:"license_status"`
+	LicenseSubStatus types.String `tfsdk:"license_sub_status"`
+	ExpiryDate       types.String `tfsdk:"expiry_date"`
 }
 
 func (r *CMNextLicenseActivateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,7 +50,10 @@ func (r *CMNextLicenseActivateResource) Metadata(ctx context.Context, req resour
 
 func (r *CMNextLicenseActivateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used for Activate/Deactivate License for Instances on Central Manager Using JWT Token",
+		MarkdownDescription: "Resource used for Activate/Deactivate License for Instances on Central Manager Using JWT Token.\n\n" +
+			"Instances added to `instances` are activated and instances removed from it are deactivated. When `jwt_id` of an instance changes, " +
+			"the license of the instance is deactivated and activated again with the new JWT token. Instances whose license is no longer `Active` " +
+			"are activated again on the next apply.",
 		Attributes: map[string]schema.Attribute{
 			"instances": schema.ListNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
@@ -57,6 +66,22 @@ func (r *CMNextLicenseActivateResource) Schema(ctx context.Context, req resource
 							Required:            true,
 							MarkdownDescription: "JWT ID to be used to activate the license",
 						},
+						"instance_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the instance on Central Manager.",
+						},
+						"license_status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "License status of the instance, such as `Active`.",
+						},
+						"license_sub_status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "License sub status of the instance, such as `ACK_VERIFICATION_COMPLETE`.",
+						},
+						"expiry_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Expiry date of the license of the instance.",
+						},
 					},
 				},
 				Required:            true,
@@ -77,6 +102,51 @@ func (r *CMNextLicenseActivateResource) Configure(ctx context.Context, req resou
 	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
 }
 
+// ModifyPlan matches the planned instances to the instances in the state by address, so the license status of
+// unchanged instances is kept and instances whose license is no longer active are activated again
+func (r *CMNextLicenseActivateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+	var planCfg, stateCfg *CMNextLicenseActivateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() || planCfg.Instances == nil { // coverage-ignore
+		return
+	}
+	for i, planInstance := range planCfg.Instances {
+		planCfg.Instances[i].InstanceId = types.StringUnknown()
+		planCfg.Instances[i].LicenseStatus = types.StringUnknown()
+		planCfg.Instances[i].LicenseSubStatus = types.StringUnknown()
+		planCfg.Instances[i].ExpiryDate = types.StringUnknown()
+		for _, stateInstance := range stateCfg.Instances {
+			if planInstance.InstanceAddress.IsUnknown() || !planInstance.InstanceAddress.Equal(stateInstance.InstanceAddress) {
+				continue
+			}
+			if !stateInstance.InstanceId.IsNull() {
+				planCfg.Instances[i].InstanceId = stateInstance.InstanceId
+			}
+			if !planInstance.JwtId.Equal(stateInstance.JwtId) {
+				continue
+			}
+			if licenseInactive(stateInstance) {
+				tflog.Info(ctx, fmt.Sprintf("License of instance %s is %s, planning activation", stateInstance.InstanceAddress.ValueString(), stateInstance.LicenseStatus.ValueString()))
+				continue
+			}
+			planCfg.Instances[i].LicenseStatus = stateInstance.LicenseStatus
+			planCfg.Instances[i].LicenseSubStatus = stateInstance.LicenseSubStatus
+			planCfg.Instances[i].ExpiryDate = stateInstance.ExpiryDate
+		}
+	}
+	planCfg.Id = types.StringValue(licenseActivateId(planCfg))
+	for _, instance := range planCfg.Instances {
+		if instance.InstanceId.IsUnknown() {
+			planCfg.Id = types.StringUnknown()
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, planCfg)...)
+}
+
 func (r *CMNextLicenseActivateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var resCfg *CMNextLicenseActivateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
@@ -84,21 +154,19 @@ func (r *CMNextLicenseActivateResource) Create(ctx context.Context, req resource
 		return
 	}
 	tflog.Info(ctx, "[CREATE] Activate License for Instances on Central Manager Using JWT Token")
-	providerConfig := getCMNextLicenseActivateConfig(ctx, r.client, resCfg)
+	providerConfig, err := getCMNextLicenseActivateConfig(ctx, r.client, resCfg)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Activate license", fmt.Sprintf("%+v", err))
+		return
+	}
 	respData, err := r.client.PostActivateLicense(providerConfig)
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Failed to Activate license", fmt.Sprintf("%+v", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] License Task IDs :%+v\n", respData))
-	var deviceID []string
-	for _, value := range providerConfig {
-		deviceID = append(deviceID, value.DigitalAssetId)
-	}
-	tflog.Info(ctx, fmt.Sprintf("Device ID : %+v", deviceID))
-	// join all keys into a single string
-	deviceString := strings.Join(deviceID, ",")
-	resCfg.Id = types.StringValue(deviceString)
+	resCfg.Id = types.StringValue(licenseActivateId(resCfg))
+	resp.Diagnostics.Append(r.licenseStatusToState(ctx, resCfg)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
 }
 
@@ -108,43 +176,120 @@ func (r *CMNextLicenseActivateResource) Read(ctx context.Context, req resource.R
 	if resp.Diagnostics.HasError() { // coverage-ignore
 		return
 	}
-	id := stateCfg.Id.ValueString()
-	tflog.Info(ctx, fmt.Sprintf("Instance IDs : %+v", id))
-	deviceIDs := strings.Split(id, ",")
-	deactivateReq := &bigipnextsdk.LicenseDeactivaeReq{}
-	digitalAssetID := deviceIDs
-	deactivateReq.DigitalAssetIds = digitalAssetID
+	tflog.Info(ctx, fmt.Sprintf("Instance IDs : %+v", stateCfg.Id.ValueString()))
+	// instance_id is not set in the state of earlier versions, the ID holds the instance IDs in the same order
+	deviceIDs := strings.Split(stateCfg.Id.ValueString(), ",")
+	for i := range stateCfg.Instances {
+		if stateCfg.Instances[i].InstanceId.IsNull() && i < len(deviceIDs) {
+			stateCfg.Instances[i].InstanceId = types.StringValue(deviceIDs[i])
+		}
+	}
+	resp.Diagnostics.Append(r.licenseStatusToState(ctx, stateCfg)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
 
+// licenseStatusToState sets the license status of every instance from the license info of Central Manager
+func (r *CMNextLicenseActivateResource) licenseStatusToState(ctx context.Context, data *CMNextLicenseActivateResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	deactivateReq := &bigipnextsdk.LicenseDeactivaeReq{}
+	for _, instance := range data.Instances {
+		deactivateReq.DigitalAssetIds = append(deactivateReq.DigitalAssetIds, instance.InstanceId.ValueString())
+	}
 	licenseInfo, err := r.client.PostLicenseInfo(deactivateReq)
 	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError("Failed to get License Info", fmt.Sprintf("%+v", err))
-		return
+		diags.AddError("Failed to get License Info", fmt.Sprintf("%+v", err))
+		return diags
 	}
-	// get the license info by loop over map
-	var licenseStatus []string
-	for _, val := range licenseInfo.(map[string]interface{}) {
-		licenseStatus = append(licenseStatus, val.(map[string]interface{})["deviceLicenseStatus"].(map[string]interface{})["licenseStatus"].(string))
-		tflog.Info(ctx, fmt.Sprintf("License Info : %+v", val.(map[string]interface{})["deviceLicenseStatus"].(map[string]interface{})["licenseStatus"]))
+	tflog.Info(ctx, fmt.Sprintf("Instance License Info : %+v", licenseInfo))
+	licenseMap, _ := licenseInfo.(map[string]interface{})
+	for i, instance := range data.Instances {
+		data.Instances[i].LicenseStatus = types.StringNull()
+		data.Instances[i].LicenseSubStatus = types.StringNull()
+		data.Instances[i].ExpiryDate = types.StringNull()
+		deviceInfo, _ := licenseMap[instance.InstanceId.ValueString()].(map[string]interface{})
+		licenseStatus, ok := deviceInfo["deviceLicenseStatus"].(map[string]interface{})
+		if !ok {
+			tflog.Warn(ctx, fmt.Sprintf("License status of instance %s not found", instance.InstanceAddress.ValueString()))
+			continue
+		}
+		if val, ok := licenseStatus["licenseStatus"].(string); ok {
+			data.Instances[i].LicenseStatus = types.StringValue(val)
+		}
+		if val, ok := licenseStatus["licenseSubStatus"].(string); ok {
+			data.Instances[i].LicenseSubStatus = types.StringValue(val)
+		}
+		if val, ok := licenseStatus["expiryDate"].(string); ok {
+			data.Instances[i].ExpiryDate = types.StringValue(val)
+		}
 	}
-	tflog.Info(ctx, fmt.Sprintf("Instance License Info : %+v", licenseStatus))
-	// diags := resp.State.SetAttribute(ctx, path.Root("license_status"), licenseStatus)
-	// resp.Diagnostics.Append(diags...)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+	return diags
 }
 
-// func (r *CMNextLicenseActivateResource) NextJwtTokenResourceModeltoState(ctx context.Context, respData interface{}, data *CMNextLicenseActivateResourceModel) {
-// 	tflog.Debug(ctx, fmt.Sprintf("respData  %+v", respData))
-// }
-
 func (r *CMNextLicenseActivateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// var resCfg *CMNextLicenseActivateResourceModel
-	// resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	var resCfg, stateCfg *CMNextLicenseActivateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, "[UPDATE] Update License activation of Instances on Central Manager")
+	providerConfig, err := getCMNextLicenseActivateConfig(ctx, r.client, resCfg)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to Update license activation", fmt.Sprintf("%+v", err))
+		return
+	}
+
+	stateInstances := make(map[string]InstanceActivateModel)
+	var stateIDs []string
+	deviceIDs := strings.Split(stateCfg.Id.ValueString(), ",")
+	for i, instance := range stateCfg.Instances {
+		if instance.InstanceId.IsNull() && i < len(deviceIDs) {
+			instance.InstanceId = types.StringValue(deviceIDs[i])
+		}
+		stateInstances[instance.InstanceId.ValueString()] = instance
+		stateIDs = append(stateIDs, instance.InstanceId.ValueString())
+	}
+	planned := make(map[string]bool)
+	deactivateReq := &bigipnextsdk.LicenseDeactivaeReq{}
+	var activateReq []*bigipnextsdk.LicenseReq
+	for _, licenseReq := range providerConfig {
+		planned[licenseReq.DigitalAssetId] = true
+		stateInstance, ok := stateInstances[licenseReq.DigitalAssetId]
+		switch {
+		case !ok:
+			activateReq = append(activateReq, licenseReq)
+		case stateInstance.JwtId.ValueString() != licenseReq.JwtId:
+			tflog.Info(ctx, fmt.Sprintf("JWT of instance %s changed, activating license again", licenseReq.DigitalAssetId))
+			deactivateReq.DigitalAssetIds = append(deactivateReq.DigitalAssetIds, licenseReq.DigitalAssetId)
+			activateReq = append(activateReq, licenseReq)
+		case licenseInactive(stateInstance):
+			activateReq = append(activateReq, licenseReq)
+		}
+	}
+	for _, id := range stateIDs {
+		if !planned[id] {
+			tflog.Info(ctx, fmt.Sprintf("Instance %s removed, deactivating license", stateInstances[id].InstanceAddress.ValueString()))
+			deactivateReq.DigitalAssetIds = append(deactivateReq.DigitalAssetIds, id)
+		}
+	}
 
-	// if resp.Diagnostics.HasError() {
-	// 	return
-	// }
-	tflog.Info(ctx, "[UPDATE] Update Call on Activating License Not Supported!!!!")
-	// resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
+	if len(deactivateReq.DigitalAssetIds) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("[UPDATE] Deactivating license of instances: %+v", deactivateReq.DigitalAssetIds))
+		if _, err := r.client.PostDeactivateLicense(deactivateReq); err != nil {
+			resp.Diagnostics.AddError("Failed to deactivate license", fmt.Sprintf("%+v", err))
+			return
+		}
+	}
+	if len(activateReq) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("[UPDATE] Activating license of %d instances", len(activateReq)))
+		if _, err := r.client.PostActivateLicense(activateReq); err != nil {
+			resp.Diagnostics.AddError("Failed to Activate license", fmt.Sprintf("%+v", err))
+			return
+		}
+	}
+	resCfg.Id = types.StringValue(licenseActivateId(resCfg))
+	resp.Diagnostics.Append(r.licenseStatusToState(ctx, resCfg)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
 }
 
 func (r *CMNextLicenseActivateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -171,15 +316,34 @@ func (r *CMNextLicenseActivateResource) ImportState(ctx context.Context, req res
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func getCMNextLicenseActivateConfig(ctx context.Context, p *bigipnextsdk.BigipNextCM, data *CMNextLicenseActivateResourceModel) []*bigipnextsdk.LicenseReq {
+// licenseInactive returns true when the license of the instance is known and not active
+func licenseInactive(instance InstanceActivateModel) bool {
+	return !instance.LicenseStatus.IsNull() && !instance.LicenseStatus.IsUnknown() && instance.LicenseStatus.ValueString() != "Active"
+}
+
+func licenseActivateId(data *CMNextLicenseActivateResourceModel) string {
+	var deviceID []string
+	for _, instance := range data.Instances {
+		deviceID = append(deviceID, instance.InstanceId.ValueString())
+	}
+	// join all keys into a single string
+	return strings.Join(deviceID, ",")
+}
+
+// getCMNextLicenseActivateConfig looks up the instance ID of every instance address and sets instance_id
+func getCMNextLicenseActivateConfig(ctx context.Context, p *bigipnextsdk.BigipNextCM, data *CMNextLicenseActivateResourceModel) ([]*bigipnextsdk.LicenseReq, error) {
 	listLicenseRequest := []*bigipnextsdk.LicenseReq{}
-	for _, val := range data.Instances {
+	for i, val := range data.Instances {
 		licenseRequest := &bigipnextsdk.LicenseReq{}
 		licenseRequest.JwtId = val.JwtId.ValueString()
-		deviceID, _ := p.GetDeviceIdByIp(val.InstanceAddress.ValueString())
+		deviceID, err := p.GetDeviceIdByIp(val.InstanceAddress.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("unable to find instance %s on Central Manager: %s", val.InstanceAddress.ValueString(), err)
+		}
 		licenseRequest.DigitalAssetId = *deviceID
+		data.Instances[i].InstanceId = types.StringValue(*deviceID)
 		tflog.Info(ctx, fmt.Sprintf("licenseRequest:%+v", licenseRequest))
 		listLicenseRequest = append(listLicenseRequest, licenseRequest)
 	}
-	return listLicenseRequest
+	return listLicenseRequest, nil
 }
@@ -3,13 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -38,6 +42,8 @@ type CMNextUpgradeResourceModel struct {
 	PartitionUsername types.String `tfsdk:"partition_username"`
 	PartitionPassword types.String `tfsdk:"partition_password"`
 	TenantName        types.String `tfsdk:"tenant_name"`
+	TargetVersion     types.String `tfsdk:"target_version"`
+	CurrentVersion    types.String `tfsdk:"current_version"`
 	Timeout           types.Int64  `tfsdk:"timeout"`
 	Id                types.String `tfsdk:"id"`
 }
@@ -48,7 +54,9 @@ func (r *CMNextUpgradeResource) Metadata(ctx context.Context, req resource.Metad
 
 func (r *CMNextUpgradeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used to upgrade BIG-IP Next instances that are managed by the BIG-IP Central Manager.",
+		MarkdownDescription: "Resource used to upgrade BIG-IP Next instances that are managed by the BIG-IP Central Manager.\n\n" +
+			"The upgrade is skipped when the instance already runs `target_version`. Before a VE upgrade is started the provider checks that the instance is not part of an HA cluster," +
+			" that the image and signature files are present on the instance and that the instance has enough free disk space for the image, when the instance reports its disk usage.",
 		Attributes: map[string]schema.Attribute{
 			"image_name": schema.StringAttribute{
 				MarkdownDescription: "The name of the BIG-IP Next image file that is to be used to upgrade the BIG-IP Next instance, in case the upgrade_type is 'appliance'" +
@@ -108,6 +116,22 @@ func (r *CMNextUpgradeResource) Schema(ctx context.Context, req resource.SchemaR
 					requiredIf(path.Root("upgrade_type"), "appliance"),
 				},
 			},
+			"target_version": schema.StringAttribute{
+				MarkdownDescription: "The BIG-IP Next version the instance is upgraded to, e.g. `20.3.0-2.713.1`. When not set it is derived from `image_name`," +
+					" the upgrade is skipped when the instance already runs this version.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(nextVersionRegexpFull, "must be a BIG-IP Next version like 20.3.0 or 20.3.0-2.713.1"),
+				},
+				PlanModifiers: []planmodifier.String{
+					targetVersionFromImageName(),
+				},
+			},
+			"current_version": schema.StringAttribute{
+				MarkdownDescription: "The software version of the BIG-IP Next instance as reported by the Central Manager inventory.",
+				Computed:            true,
+			},
 			"timeout": schema.Int64Attribute{
 				MarkdownDescription: "The time in seconds to wait for the upgrade process to complete, the default value is 300 seconds.",
 				Default:             int64default.StaticInt64(300),
@@ -117,6 +141,9 @@ func (r *CMNextUpgradeResource) Schema(ctx context.Context, req resource.SchemaR
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Unique Identifier for the resource.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
@@ -129,21 +156,89 @@ func (r *CMNextUpgradeResource) Configure(ctx context.Context, req resource.Conf
 func (r *CMNextUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var resCfg *CMNextUpgradeResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	r.upgradeNextInstance(ctx, resCfg, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMNextUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMNextUpgradeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	nextInstanceId, err := r.client.GetNextInstanceID(resCfg.NextInstanceIP.ValueString())
+	instance, err := getNextInstance(r.client, stateCfg.NextInstanceIP.ValueString())
 	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			tflog.Warn(ctx, fmt.Sprintf("NEXT instance %s not found, removing resource from state", stateCfg.NextInstanceIP.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
 		resp.Diagnostics.AddError(
+			"error while fetching the next instance",
+			err.Error(),
+		)
+		return
+	}
+	stateCfg.CurrentVersion = types.StringValue(instance.Version)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *CMNextUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg *CMNextUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	r.upgradeNextInstance(ctx, resCfg, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+// upgradeNextInstance upgrades the instance to the target version unless it already runs it,
+// the pre-upgrade checks are done before the upgrade task is started
+func (r *CMNextUpgradeResource) upgradeNextInstance(ctx context.Context, resCfg *CMNextUpgradeResourceModel, diags *diag.Diagnostics) {
+	instance, err := getNextInstance(r.client, resCfg.NextInstanceIP.ValueString())
+	if err != nil {
+		diags.AddError(
 			"error while fetching the next instance id",
 			err.Error(),
 		)
 		return
 	}
+	nextInstanceId := instance.Id
+	resCfg.Id = types.StringValue(nextInstanceId)
 
 	tflog.Debug(
 		ctx,
-		fmt.Sprintf("Instance id of %v is %v: ", resCfg.NextInstanceIP.ValueString(), nextInstanceId),
+		fmt.Sprintf("Instance id of %v is %v, running version %v", resCfg.NextInstanceIP.ValueString(), nextInstanceId, instance.Version),
 	)
 
+	if nextVersionMatches(instance.Version, resCfg.TargetVersion.ValueString()) {
+		tflog.Info(ctx, fmt.Sprintf("NEXT instance %v is already on version %v, skipping upgrade", resCfg.NextInstanceIP.ValueString(), instance.Version))
+		resCfg.CurrentVersion = types.StringValue(instance.Version)
+		return
+	}
+
+	if strings.EqualFold(instance.Mode, "HA") {
+		diags.AddError(
+			"pre-upgrade check failed",
+			fmt.Sprintf("NEXT instance %v is part of an HA cluster, HA instances need to be upgraded as a cluster", resCfg.NextInstanceIP.ValueString()),
+		)
+		return
+	}
+
 	var upgradeTaskId string
 	if strings.ToLower(resCfg.UpgradeType.ValueString()) == "ve" {
 
@@ -153,16 +248,21 @@ func (r *CMNextUpgradeResource) Create(ctx context.Context, req resource.CreateR
 			resCfg.SignatureFilename.ValueString(),
 		)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"error while fetching the image and signature name",
 				err.Error(),
 			)
 			return
 		}
 
+		r.checkDiskSpace(ctx, nextInstanceId, resCfg.ImageName.ValueString(), diags)
+		if diags.HasError() {
+			return
+		}
+
 		upgradeTaskId, err = r.client.UpgradeVE(nextInstanceId, image_name, signature_name)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"error while initiating the upgrade process",
 				err.Error(),
 			)
@@ -181,7 +281,7 @@ func (r *CMNextUpgradeResource) Create(ctx context.Context, req resource.CreateR
 
 		upgradeTaskId, err = r.client.UpgradeNextInstanceAppliance(nextInstanceId, body)
 		if err != nil {
-			resp.Diagnostics.AddError(
+			diags.AddError(
 				"error while initiating the upgrade process",
 				err.Error(),
 			)
@@ -192,134 +292,166 @@ func (r *CMNextUpgradeResource) Create(ctx context.Context, req resource.CreateR
 	tflog.Debug(ctx, "Upgrade task id: "+upgradeTaskId)
 	status, details, err := r.client.WaitForNextInstanceUpgrade(upgradeTaskId, resCfg.Timeout.ValueInt64())
 	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"error while waiting for the upgrade to complete",
 			err.Error(),
 		)
 		return
 	}
 
+	if status == "failed" {
+		diags.AddError(
+			"upgrade process failed",
+			"upgrade process failed with error: "+details,
+		)
+		return
+	}
+
 	if status != "completed" { // coverage-ignore
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"upgrade process taking too long",
 			"upgrade process not complete yet: "+details,
 		)
 		return
 	}
 
-	if status == "failed" { // coverage-ignore
-		resp.Diagnostics.AddError(
-			"upgrade process failed",
-			"upgrade process failed with error: "+details,
+	instance, err = getNextInstance(r.client, resCfg.NextInstanceIP.ValueString())
+	if err != nil { // coverage-ignore
+		diags.AddError(
+			"error while fetching the next instance",
+			err.Error(),
 		)
 		return
 	}
-
-	resCfg.Id = types.StringValue(nextInstanceId)
-	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+	resCfg.CurrentVersion = types.StringValue(instance.Version)
 }
 
-func (r *CMNextUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var stateCfg *CMNextUpgradeResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
-
-	if resp.Diagnostics.HasError() {
+// checkDiskSpace verifies the instance has room to unpack the upgrade image. The disk usage proxy path is not
+// available on every NEXT version, the check is skipped with a warning when the instance does not support it
+// or does not report its disk usage
+func (r *CMNextUpgradeResource) checkDiskSpace(ctx context.Context, nextInstanceId, imageName string, diags *diag.Diagnostics) {
+	diskUsage, err := getNextInstanceDiskUsage(r.client, nextInstanceId)
+	if err != nil && isNotSupportedError(err) {
+		diags.AddWarning(
+			"disk space check skipped",
+			fmt.Sprintf("NEXT instance %v does not support the disk usage API, got error: %s", nextInstanceId, err),
+		)
 		return
 	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
-}
-
-func (r *CMNextUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var resCfg CMNextUpgradeResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
-
-	nextInstanceId, err := r.client.GetNextInstanceID(resCfg.NextInstanceIP.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"error while fetching the next instance id",
+		diags.AddError(
+			"pre-upgrade check failed",
 			err.Error(),
 		)
 		return
 	}
-
-	tflog.Debug(
-		ctx,
-		fmt.Sprintf("Instance id of %v is %v: ", resCfg.NextInstanceIP.ValueString(), nextInstanceId),
-	)
-
-	var upgradeTaskId string
-
-	if strings.ToLower(resCfg.UpgradeType.ValueString()) == "ve" {
-
-		image_name, signature_name, err := r.client.GetImageAndSignatureName(
-			nextInstanceId,
-			resCfg.ImageName.ValueString(),
-			resCfg.SignatureFilename.ValueString(),
+	if diskUsage.Total == 0 {
+		diags.AddWarning(
+			"disk space check skipped",
+			fmt.Sprintf("NEXT instance %v does not report its disk usage", nextInstanceId),
 		)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"error while fetching the image and signature name",
-				err.Error(),
+		return
+	}
+	files, err := getNextInstanceFiles(r.client, nextInstanceId)
+	if err != nil { // coverage-ignore
+		diags.AddError(
+			"pre-upgrade check failed",
+			err.Error(),
+		)
+		return
+	}
+	for _, file := range files.Embedded.Files {
+		if file.Name == imageName && diskUsage.Available < file.Size {
+			diags.AddError(
+				"pre-upgrade check failed",
+				fmt.Sprintf("not enough disk space on NEXT instance to upgrade with %v, available: %d bytes, required: %d bytes", imageName, diskUsage.Available, file.Size),
 			)
 			return
 		}
+	}
+}
 
-		upgradeTaskId, err = r.client.UpgradeVE(nextInstanceId, image_name, signature_name)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"error while initiating the upgrade process",
-				err.Error(),
-			)
-			return
+// isNotSupportedError reports whether err is CM or the instance answering that the API path does not exist
+func isNotSupportedError(err error) bool {
+	for _, code := range []string{`"code":404`, `"code":405`, `"code":501`} {
+		if strings.Contains(err.Error(), code) {
+			return true
 		}
-	} else if strings.ToLower(resCfg.UpgradeType.ValueString()) == "appliance" {
-		body := make(map[string]interface{})
-		body["partition_address"] = resCfg.PartitionAddress.ValueString()
-		body["partition_port"] = resCfg.PartitionPort.ValueInt64()
-		body["partition_username"] = resCfg.PartitionUsername.ValueString()
-		body["partition_password"] = resCfg.PartitionPassword.ValueString()
-		body["tenant_name"] = resCfg.TenantName.ValueString()
-		body["image_name"] = resCfg.ImageName.ValueString()
+	}
+	return false
+}
 
-		upgradeTaskId, err = r.client.UpgradeNextInstanceAppliance(nextInstanceId, body)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"error while initiating the upgrade process",
-				err.Error(),
-			)
-			return
-		}
+func (r *CMNextUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(""))
+}
+
+// BIG-IP Next versions look like 20.3.0, 20.3.0-2.713.1 or 20.2.1-2.430.2+0.0.48
+const nextVersionPattern = `\d+\.\d+\.\d+(?:-\d+\.\d+\.\d+)?(?:\+\d+(?:\.\d+)*)?`
+
+var (
+	nextVersionRegexp     = regexp.MustCompile(nextVersionPattern)
+	nextVersionRegexpFull = regexp.MustCompile(`^` + nextVersionPattern + `$`)
+)
+
+// nextVersionFromImageName returns the version part of an image name like BIG-IP-Next-20.3.0-2.713.1.tgz
+func nextVersionFromImageName(imageName string) string {
+	return nextVersionRegexp.FindString(imageName)
+}
+
+// nextVersionMatches reports whether the instance version is the target version,
+// a short version like 20.3.0 matches any build of that release
+func nextVersionMatches(current, target string) bool {
+	if current == "" || target == "" {
+		return false
 	}
+	if current == target {
+		return true
+	}
+	return isNextVersionPrefix(target, current) || isNextVersionPrefix(current, target)
+}
 
-	status, details, err := r.client.WaitForNextInstanceUpgrade(upgradeTaskId, resCfg.Timeout.ValueInt64())
-	if err != nil { // coverage-ignore
-		resp.Diagnostics.AddError(
-			"error while waiting for the upgrade to complete",
-			err.Error(),
-		)
+func isNextVersionPrefix(prefix, version string) bool {
+	return strings.HasPrefix(version, prefix+"-") || strings.HasPrefix(version, prefix+"+")
+}
+
+func targetVersionFromImageName() planmodifier.String {
+	return targetVersionPlanModifier{}
+}
+
+type targetVersionPlanModifier struct{}
+
+func (m targetVersionPlanModifier) Description(ctx context.Context) string {
+	return m.MarkdownDescription(ctx)
+}
+
+func (m targetVersionPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return "Derives the target version from image_name when it is not set and checks that both are consistent"
+}
+
+func (m targetVersionPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var imageName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("image_name"), &imageName)...)
+	if resp.Diagnostics.HasError() || imageName.IsUnknown() || req.ConfigValue.IsUnknown() {
 		return
 	}
-
-	if status != "completed" { // coverage-ignore
-		resp.Diagnostics.AddError(
-			"upgrade process failed or taking too long",
-			"upgrade process not complete yet: "+details,
-		)
+	imageVersion := nextVersionFromImageName(imageName.ValueString())
+	if !req.ConfigValue.IsNull() {
+		if imageVersion != "" && !nextVersionMatches(imageVersion, req.ConfigValue.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"validation error: ",
+				fmt.Sprintf("target_version %v does not match the version %v of image_name %v", req.ConfigValue.ValueString(), imageVersion, imageName.ValueString()),
+			)
+		}
 		return
 	}
-
-	if status == "failed" { // coverage-ignore
-		resp.Diagnostics.AddError(
-			"upgrade process failed",
-			"upgrade process failed with error: "+details,
+	if imageVersion == "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"validation error: ",
+			fmt.Sprintf("target_version is required, the version cannot be derived from image_name %v", imageName.ValueString()),
 		)
 		return
 	}
-
-	resCfg.Id = types.StringValue(nextInstanceId)
-	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
-}
-
-func (r *CMNextUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(""))
+	resp.PlanValue = types.StringValue(imageVersion)
 }
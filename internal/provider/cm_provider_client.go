@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+const uriCMProviders = "/v1/spaces/default/providers"
+
+// cmProviderSummary is a provider as listed by GET /v1/spaces/default/providers
+type cmProviderSummary struct {
+	Id        string        `json:"provider_id"`
+	Name      string        `json:"provider_name"`
+	Type      string        `json:"provider_type"`
+	Host      string        `json:"provider_host"`
+	Username  string        `json:"provider_username"`
+	Updated   string        `json:"updated"`
+	Instances []interface{} `json:"instances"`
+}
+
+// listCMProviders returns the providers of all types configured on CM
+func listCMProviders(client *bigipnextsdk.BigipNextCM) ([]cmProviderSummary, error) {
+	respData, err := client.GetDeviceProviders()
+	if err != nil {
+		return nil, err
+	}
+	var providers []cmProviderSummary
+	if err := json.Unmarshal(respData, &providers); err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// testCMProviderConnection asks CM to connect to the provider described by config with its credentials,
+// without saving the provider
+// /v1/spaces/default/providers/{vsphere|f5os}/test-connection
+//
+// An error is returned when CM could not reach or authenticate to the provider, or does not report a successful
+// status. The certificate fingerprint of the provider is not accepted on behalf of the user: a provider with an
+// untrusted certificate fails the test unless config has its fingerprint
+func testCMProviderConnection(client *bigipnextsdk.BigipNextCM, config *bigipnextsdk.DeviceProviderReq) error {
+	providerUrl := fmt.Sprintf("%s/vsphere/test-connection", uriCMProviders)
+	if config.Type == "VELOS" || config.Type == "RSERIES" {
+		providerUrl = fmt.Sprintf("%s/f5os/test-connection", uriCMProviders)
+	}
+	body, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	respData, err := client.PostCMRequest(providerUrl, body)
+	if err != nil {
+		return err
+	}
+	var result struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}
+	if len(respData) > 0 {
+		if err := json.Unmarshal(respData, &result); err != nil {
+			return err
+		}
+	}
+	switch strings.ToUpper(result.Status) {
+	case "SUCCESS", "CONNECTED", "OK":
+		return nil
+	case "":
+		result.Status = "unknown"
+	}
+	if result.Message == "" {
+		result.Message = "no reason given by CM"
+	}
+	return fmt.Errorf("connection test of provider %s failed with status %s: %s", config.Connection.Host, result.Status, result.Message)
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMProviderDataSource{}
+)
+
+func NewCMProviderDataSource() datasource.DataSource {
+	return &CMProviderDataSource{}
+}
+
+// CMProviderDataSource defines the data source implementation.
+type CMProviderDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMProviderDataSourceModel describes the data source data model.
+type CMProviderDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Type          types.String `tfsdk:"type"`
+	Address       types.String `tfsdk:"address"`
+	Username      types.String `tfsdk:"username"`
+	InstanceCount types.Int64  `tfsdk:"instance_count"`
+	Updated       types.String `tfsdk:"updated"`
+}
+
+func (d *CMProviderDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_provider"
+}
+
+func (d *CMProviderDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := cmProviderAttributes()
+	attributes["name"] = schema.StringAttribute{
+		Required:            true,
+		MarkdownDescription: "Name of the provider on BIG-IP Next Central Manager.",
+	}
+	attributes["type"] = schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		MarkdownDescription: "Type of the provider, one of `RSERIES`, `VELOS` or `VSPHERE`. When set, the data source fails if the provider named `name` is of another type.",
+		Validators: []validator.String{
+			stringvalidator.OneOf("RSERIES", "VELOS", "VSPHERE"),
+		},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get an existing provider of BIG-IP Next Central Manager by name, for instance to reference it in `bigipnext_cm_deploy_vmware` or `bigipnext_cm_deploy_f5os`.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *CMProviderDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMProviderDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMProviderDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	found, err := findCMProvider(d.client, data.Name.ValueString(), data.Type.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Lookup Error", err.Error())
+		return
+	}
+	item := cmProviderItemModel(*found)
+	data.Id = item.Id
+	data.Type = item.Type
+	data.Address = item.Address
+	data.Username = item.Username
+	data.InstanceCount = item.InstanceCount
+	data.Updated = item.Updated
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findCMProvider returns the provider of CM named name, which must be of type providerType unless it is empty
+func findCMProvider(client *bigipnextsdk.BigipNextCM, name, providerType string) (*cmProviderSummary, error) {
+	providers, err := listCMProviders(client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read providers, got error: %s", err)
+	}
+	return cmProviderByName(providers, name, providerType)
+}
+
+// cmProviderByName returns the provider named name in providers, which must be of one of providerTypes if any
+func cmProviderByName(providers []cmProviderSummary, name string, providerTypes ...string) (*cmProviderSummary, error) {
+	var names []string
+	for i, provider := range providers {
+		if provider.Name != name {
+			names = append(names, provider.Name)
+			continue
+		}
+		for _, providerType := range providerTypes {
+			if providerType == "" || strings.EqualFold(provider.Type, providerType) {
+				return &providers[i], nil
+			}
+		}
+		if len(providerTypes) == 0 {
+			return &providers[i], nil
+		}
+		return nil, fmt.Errorf("provider %q is of type %s, not %s", name, strings.ToUpper(provider.Type), strings.Join(providerTypes, " or "))
+	}
+	return nil, fmt.Errorf("no provider named %q on Central Manager, existing providers: [%s]", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMProvidersDataSource{}
+)
+
+func NewCMProvidersDataSource() datasource.DataSource {
+	return &CMProvidersDataSource{}
+}
+
+// CMProvidersDataSource defines the data source implementation.
+type CMProvidersDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMProvidersDataSourceModel describes the data source data model.
+type CMProvidersDataSourceModel struct {
+	Type      types.String          `tfsdk:"type"`
+	Providers []CMProviderItemModel `tfsdk:"providers"`
+	ID        types.String          `tfsdk:"id"`
+}
+
+type CMProviderItemModel struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Type          types.String `tfsdk:"type"`
+	Address       types.String `tfsdk:"address"`
+	Username      types.String `tfsdk:"username"`
+	InstanceCount types.Int64  `tfsdk:"instance_count"`
+	Updated       types.String `tfsdk:"updated"`
+}
+
+func (d *CMProvidersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_providers"
+}
+
+func (d *CMProvidersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the providers configured on BIG-IP Next Central Manager, optionally only of one type.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return the providers of this type, one of `RSERIES`, `VELOS` or `VSPHERE`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("RSERIES", "VELOS", "VSPHERE"),
+				},
+			},
+			"providers": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of providers, sorted as returned by Central Manager.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: cmProviderAttributes(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this data source.",
+			},
+		},
+	}
+}
+
+// cmProviderAttributes returns the attributes of a provider in the provider data sources
+func cmProviderAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "ID of the provider.",
+		},
+		"name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Name of the provider, to be used as `provider_name` of the deploy resources.",
+		},
+		"type": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Type of the provider, `RSERIES`, `VELOS` or `VSPHERE`.",
+		},
+		"address": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Address of the provider to which Central Manager connects.",
+		},
+		"username": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Username that Central Manager uses when connecting with the provider.",
+		},
+		"instance_count": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "Number of BIG-IP Next instances deployed with the provider.",
+		},
+		"updated": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The timestamp of the last update of the provider. In ISO 8601 format.",
+		},
+	}
+}
+
+func (d *CMProvidersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMProvidersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMProvidersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	providers, err := listCMProviders(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read providers, got error: %s", err))
+		return
+	}
+	data.Providers = []CMProviderItemModel{}
+	for _, provider := range providers {
+		if !data.Type.IsNull() && !strings.EqualFold(provider.Type, data.Type.ValueString()) {
+			continue
+		}
+		data.Providers = append(data.Providers, cmProviderItemModel(provider))
+	}
+	data.ID = types.StringValue("providers")
+	if !data.Type.IsNull() {
+		data.ID = types.StringValue(fmt.Sprintf("providers-%s", data.Type.ValueString()))
+	}
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func cmProviderItemModel(provider cmProviderSummary) CMProviderItemModel {
+	return CMProviderItemModel{
+		Id:            types.StringValue(provider.Id),
+		Name:          types.StringValue(provider.Name),
+		Type:          types.StringValue(strings.ToUpper(provider.Type)),
+		Address:       types.StringValue(provider.Host),
+		Username:      types.StringValue(provider.Username),
+		InstanceCount: types.Int64Value(int64(len(provider.Instances))),
+		Updated:       types.StringValue(provider.Updated),
+	}
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource                   = &CMRestoreResource{}
+	_ resource.ResourceWithValidateConfig = &CMRestoreResource{}
+)
+
+func NewCMRestoreResource() resource.Resource {
+	return &CMRestoreResource{}
+}
+
+type CMRestoreResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type CMRestoreResourceModel struct {
+	FileId             types.String `tfsdk:"file_id"`
+	EncryptionPassword types.String `tfsdk:"encryption_password"`
+	ConfirmRestore     types.Bool   `tfsdk:"confirm_restore"`
+	FileName           types.String `tfsdk:"file_name"`
+	FileDate           types.String `tfsdk:"file_date"`
+	CMVersion          types.String `tfsdk:"cm_version"`
+	Id                 types.String `tfsdk:"id"`
+}
+
+func (r *CMRestoreResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_restore"
+}
+
+func (r *CMRestoreResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to restore BIG-IP Next Central Manager from a backup file.\n\n" +
+			"~> **NOTE** A restore replaces the current Central Manager configuration and cannot be reverted. " +
+			"When doing `terraform destroy` the resource is only removed from the state.",
+		Attributes: map[string]schema.Attribute{
+			"file_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "File ID of the backup to restore, as listed by the `bigipnext_cm_backups` data source.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"encryption_password": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Password the backup file was encrypted with.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"confirm_restore": schema.BoolAttribute{
+				Required:            true,
+				MarkdownDescription: "Must be set to `true` to confirm that the Central Manager configuration is to be replaced by the backup.",
+			},
+			"file_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the restored backup file.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"file_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Creation time of the restored backup file.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cm_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Central Manager version the restored backup was taken on.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique Identifier for the resource, file ID of the restored backup.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CMRestoreResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var confirmRestore types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("confirm_restore"), &confirmRestore)...)
+	if resp.Diagnostics.HasError() || confirmRestore.IsNull() || confirmRestore.IsUnknown() {
+		return
+	}
+	if !confirmRestore.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("confirm_restore"),
+			"Restore Not Confirmed",
+			"Set confirm_restore to true to replace the Central Manager configuration with the backup.",
+		)
+	}
+}
+
+func (r *CMRestoreResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *CMRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *CMRestoreResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	fileId := resCfg.FileId.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] CM Restore from backup:%+v", fileId))
+
+	backup, err := getCMBackupByFileId(r.client, fileId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read Backups, got error: %s", err))
+		return
+	}
+	if backup == nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Backup with file ID %s not found on CM", fileId))
+		return
+	}
+
+	cmRestoreDraft := &bigipnextsdk.CMRestoreRequestDraft{
+		EncryptionPassword: resCfg.EncryptionPassword.ValueString(),
+		FileId:             backup.FileId,
+	}
+	if err = r.client.RestoreCM(cmRestoreDraft); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Restore, got error: %s", err))
+		return
+	}
+	resCfg.FileName = types.StringValue(backup.FileName)
+	resCfg.FileDate = types.StringValue(backup.FileDate)
+	resCfg.CMVersion = types.StringValue(backup.CmVersion)
+	resCfg.Id = types.StringValue(backup.FileId)
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMRestoreResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	// a restore is a one time action, removing the backup file afterwards must not trigger another restore
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *CMRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg *CMRestoreResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	// file_id and encryption_password require replacement, only confirm_restore is updated in place
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *CMRestoreResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("Restore from %s cannot be reverted, removing it from state", stateCfg.FileName.ValueString()))
+	stateCfg.Id = types.StringValue("")
+}
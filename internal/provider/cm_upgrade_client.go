@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+const (
+	uriCMUpgradeTasks = "/upgrade-manager/v1/upgrade-tasks"
+	// cmUpgradeTaskPollInterval is the longest wait between two reads of the status of a CM upgrade task
+	cmUpgradeTaskPollInterval = 10 * time.Second
+)
+
+// startCMUpgradeTask starts the CM upgrade from the uploaded upgrade bundle and returns the upgrade task ID
+// /api/upgrade-manager/v1/upgrade-tasks
+func startCMUpgradeTask(client *bigipnextsdk.BigipNextCM, fileId string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"file_id":     fileId,
+		"file_format": "tar",
+	})
+	if err != nil {
+		return "", err
+	}
+	respData, err := client.PutCMRequest(uriCMUpgradeTasks, body)
+	if err != nil {
+		return "", err
+	}
+	// {"_links":{"self":{"href":"/v1/upgrade-tasks/169653ef-1a15-4d05-b090-c07cb8ac5a43"}},"path":"/v1/upgrade-tasks/169653ef-1a15-4d05-b090-c07cb8ac5a43"}
+	var respInfo map[string]interface{}
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return "", err
+	}
+	taskPath, ok := respInfo["path"].(string)
+	if !ok {
+		return "", fmt.Errorf("upgrade task failed with :%+v", respInfo)
+	}
+	pathList := strings.Split(taskPath, "/")
+	return pathList[len(pathList)-1], nil
+}
+
+// waitCMUpgradeTask polls the CM upgrade task until it is completed or failed, for up to timeOut seconds
+// /api/upgrade-manager/v1/upgrade-tasks/{id}
+//
+// CM services restart while the upgrade is running: connection errors and 5xx responses are retried,
+// and the token is refreshed once CM rejects it
+func waitCMUpgradeTask(client *bigipnextsdk.BigipNextCM, taskId string, timeOut int) (map[string]interface{}, error) {
+	taskUrl := fmt.Sprintf("%s/%s", uriCMUpgradeTasks, taskId)
+	timeout := time.Duration(timeOut) * time.Second
+	// poll every tenth of the timeout, between 1 and 10 seconds
+	interval := timeout / 10
+	if interval < time.Second {
+		interval = time.Second
+	}
+	if interval > cmUpgradeTaskPollInterval {
+		interval = cmUpgradeTaskPollInterval
+	}
+	var status interface{}
+	endtime := time.Now().Add(timeout)
+	for time.Now().Before(endtime) {
+		respData, err := client.GetCMRequest(taskUrl)
+		if err != nil {
+			switch {
+			case strings.HasPrefix(err.Error(), `{"code":401`):
+				// a failed refresh is retried on the next poll, until the timeout
+				_ = client.CMTokenRefreshNew()
+			case strings.HasPrefix(err.Error(), `{"code":`) && !strings.HasPrefix(err.Error(), `{"code":5`):
+				return nil, err
+			}
+			time.Sleep(interval)
+			continue
+		}
+		var respInfo map[string]interface{}
+		if err = json.Unmarshal(respData, &respInfo); err != nil {
+			return nil, err
+		}
+		status = respInfo["status"]
+		switch status {
+		case "completed":
+			return respInfo, nil
+		case "failed":
+			return nil, fmt.Errorf("upgrade task failed with :%+v", respInfo)
+		}
+		time.Sleep(interval)
+	}
+	return nil, fmt.Errorf("upgrade task status is still in :%+v within timeout period of:%+v", status, timeout)
+}
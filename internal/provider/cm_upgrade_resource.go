@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource = &CMUpgradeResource{}
+)
+
+func NewCMUpgradeResource() resource.Resource {
+	return &CMUpgradeResource{}
+}
+
+// cmUpgradeBundleUploadedKey is the private state key set when the resource uploaded the upgrade bundle
+const cmUpgradeBundleUploadedKey = "bundle_uploaded"
+
+type CMUpgradeResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type CMUpgradeResourceModel struct {
+	UpgradeFilePath types.String `tfsdk:"upgrade_file_path"`
+	Sha256Checksum  types.String `tfsdk:"sha256_checksum"`
+	Timeout         types.Int64  `tfsdk:"timeout"`
+	FileId          types.String `tfsdk:"file_id"`
+	CMVersion       types.String `tfsdk:"cm_version"`
+	Id              types.String `tfsdk:"id"`
+}
+
+func (r *CMUpgradeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_upgrade"
+}
+
+func (r *CMUpgradeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to upgrade BIG-IP Next Central Manager using a CM upgrade bundle.\n\n" +
+			"~> **NOTE** An upgrade cannot be reverted. When doing `terraform destroy` the upgrade bundle is removed from Central Manager if the resource uploaded it, and the resource is removed from the state",
+		Attributes: map[string]schema.Attribute{
+			"upgrade_file_path": schema.StringAttribute{
+				MarkdownDescription: "Local path of the Central Manager upgrade bundle (`.tgz`) that is uploaded to Central Manager.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sha256_checksum": schema.StringAttribute{
+				MarkdownDescription: "Expected SHA-256 checksum of the upgrade bundle. When set, the local file is verified against it before the upload." +
+					" The checksum computed by Central Manager after the upload is always verified against the local file.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(64, 64),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				MarkdownDescription: "The time in seconds to wait for the upgrade process to complete, the default value is 3600 seconds.",
+				Default:             int64default.StaticInt64(3600),
+				Optional:            true,
+				Computed:            true,
+			},
+			"file_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the upgrade bundle uploaded to Central Manager.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cm_version": schema.StringAttribute{
+				MarkdownDescription: "Version of Central Manager after the upgrade.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique Identifier for the resource, ID of the upgrade task.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CMUpgradeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *CMUpgradeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *CMUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	filePath := resCfg.UpgradeFilePath.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] CM Upgrade using bundle:%+v", filePath))
+
+	checksum, err := fileSha256(filePath)
+	if err != nil {
+		resp.Diagnostics.AddError("Upgrade Bundle Error", fmt.Sprintf("Failed to read upgrade bundle %s, got error: %s", filePath, err))
+		return
+	}
+	if !resCfg.Sha256Checksum.IsNull() && !strings.EqualFold(resCfg.Sha256Checksum.ValueString(), checksum) {
+		resp.Diagnostics.AddError("Checksum Mismatch", fmt.Sprintf("SHA-256 checksum of %s is %s, expected %s", filePath, checksum, resCfg.Sha256Checksum.ValueString()))
+		return
+	}
+
+	fileInfo, uploaded, err := uploadFileToCM(ctx, r.client, filePath, checksum)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to upload upgrade bundle, got error: %s", err))
+		return
+	}
+	if uploaded {
+		// a bundle which was already on CM is left there on destroy
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, cmUpgradeBundleUploadedKey, []byte("true"))...)
+	}
+
+	taskId, err := startCMUpgradeTask(r.client, fileInfo.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to start CM upgrade, got error: %s", err))
+		r.deleteUploadedBundle(ctx, fileInfo.FileName, uploaded)
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] CM Upgrade task id:%+v", taskId))
+
+	_, err = waitCMUpgradeTask(r.client, taskId, int(resCfg.Timeout.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("CM upgrade did not complete, got error: %s", err))
+		r.deleteUploadedBundle(ctx, fileInfo.FileName, uploaded)
+		return
+	}
+
+	cmVersion, err := r.getCMVersion()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to read CM version, got error: %s", err))
+		return
+	}
+	resCfg.Id = types.StringValue(taskId)
+	resCfg.FileId = types.StringValue(fileInfo.Id)
+	resCfg.CMVersion = types.StringValue(cmVersion)
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMUpgradeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *CMUpgradeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	cmVersion, err := r.getCMVersion()
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to read CM version, got error: %s", err))
+		return
+	}
+	stateCfg.CMVersion = types.StringValue(cmVersion)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *CMUpgradeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg *CMUpgradeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	// only the timeout can change in place, other attributes require replacement
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *CMUpgradeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *CMUpgradeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	uploaded, diags := req.Private.GetKey(ctx, cmUpgradeBundleUploadedKey)
+	resp.Diagnostics.Append(diags...)
+	r.deleteUploadedBundle(ctx, filepath.Base(stateCfg.UpgradeFilePath.ValueString()), string(uploaded) == "true")
+	stateCfg.Id = types.StringValue("")
+}
+
+// deleteUploadedBundle deletes the upgrade bundle from CM when the resource uploaded it, a bundle which was already
+// on CM is left there
+func (r *CMUpgradeResource) deleteUploadedBundle(ctx context.Context, fileName string, uploaded bool) {
+	if !uploaded {
+		tflog.Info(ctx, fmt.Sprintf("Upgrade bundle %s was not uploaded by the resource, leaving it on CM", fileName))
+		return
+	}
+	if err := deleteCMFile(r.client, fileName); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to delete upgrade bundle %s from CM: %s", fileName, err))
+	}
+}
+
+func (r *CMUpgradeResource) getCMVersion() (string, error) {
+	infraInfo, err := r.client.GetInfraInfo()
+	if err != nil {
+		return "", err
+	}
+	version, ok := infraInfo.(map[string]interface{})["version"].(string)
+	if !ok {
+		return "", fmt.Errorf("version not found in CM infra info: %+v", infraInfo)
+	}
+	return version, nil
+}
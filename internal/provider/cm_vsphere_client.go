@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+// vsphereObject is an object of the vCenter inventory, such as a datacenter, a cluster or a datastore
+type vsphereObject struct {
+	Id   string
+	Name string
+	// Type of datastores and networks, such as VMFS or DISTRIBUTED_PORTGROUP
+	Type string
+	// FreeSpace and Capacity of datastores, in bytes
+	FreeSpace int64
+	Capacity  int64
+}
+
+// vsphereLibraryItem is an item of a vCenter content library, such as a VM template
+type vsphereLibraryItem struct {
+	Id      string
+	Name    string
+	Type    string
+	Library string
+}
+
+// getVsphereObjects lists vCenter objects through the vCenter API proxy of the CM vSphere provider
+//
+// CM returns the ID of the objects as {kind}_id, such as [{"name":"vSAN Cluster","cluster_id":"domain-c8"}],
+// vCenter as {kind}, both are accepted
+func getVsphereObjects(client *bigipnextsdk.BigipNextCM, providerID, apiPath, kind string) ([]vsphereObject, error) {
+	var items []map[string]interface{}
+	if err := getVsphereAPI(client, providerID, apiPath, &items); err != nil {
+		return nil, err
+	}
+	var objects []vsphereObject
+	for _, item := range items {
+		object := vsphereObject{
+			Id:        vsphereString(item, kind+"_id"),
+			Name:      vsphereString(item, "name"),
+			Type:      vsphereString(item, "type"),
+			FreeSpace: vsphereInt64(item, "free_space"),
+			Capacity:  vsphereInt64(item, "capacity"),
+		}
+		if object.Id == "" {
+			object.Id = vsphereString(item, kind)
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+// getVsphereDatacenters lists the datacenters of the vCenter of the provider
+func getVsphereDatacenters(client *bigipnextsdk.BigipNextCM, providerID string) ([]vsphereObject, error) {
+	return getVsphereObjects(client, providerID, "api/vcenter/datacenter", "datacenter")
+}
+
+// getVsphereClusters lists the clusters of a datacenter
+func getVsphereClusters(client *bigipnextsdk.BigipNextCM, providerID, datacenterID string) ([]vsphereObject, error) {
+	return getVsphereObjects(client, providerID, fmt.Sprintf("api/vcenter/cluster?datacenters=%s", datacenterID), "cluster")
+}
+
+// getVsphereResourcePools lists the resource pools of a cluster
+func getVsphereResourcePools(client *bigipnextsdk.BigipNextCM, providerID, clusterID string) ([]vsphereObject, error) {
+	return getVsphereObjects(client, providerID, fmt.Sprintf("api/vcenter/resource-pool?clusters=%s", clusterID), "resource_pool")
+}
+
+// getVsphereDatastores lists the datastores of a datacenter, with their free space and capacity
+func getVsphereDatastores(client *bigipnextsdk.BigipNextCM, providerID, datacenterID string) ([]vsphereObject, error) {
+	return getVsphereObjects(client, providerID, fmt.Sprintf("api/vcenter/datastore?datacenters=%s", datacenterID), "datastore")
+}
+
+// getVsphereNetworks lists the networks of a datacenter
+func getVsphereNetworks(client *bigipnextsdk.BigipNextCM, providerID, datacenterID string) ([]vsphereObject, error) {
+	return getVsphereObjects(client, providerID, fmt.Sprintf("api/vcenter/network?datacenters=%s", datacenterID), "network")
+}
+
+// getVsphereDatacenterID returns the ID of the datacenter named datacenterName
+func getVsphereDatacenterID(client *bigipnextsdk.BigipNextCM, providerID, datacenterName string) (string, error) {
+	datacenters, err := getVsphereDatacenters(client, providerID)
+	if err != nil {
+		return "", err
+	}
+	return vsphereObjectID(datacenters, "datacenter", datacenterName)
+}
+
+// getVsphereContentLibraryItems lists the items of the content libraries of the vCenter, or only of the
+// content library named libraryName when it is not empty
+//
+// The content library API of vCenter lists IDs only, so every library and item is read in turn:
+// api/content/library, api/content/library/{id}, api/content/library/item?library_id={id} and api/content/library/item/{id}
+func getVsphereContentLibraryItems(client *bigipnextsdk.BigipNextCM, providerID, libraryName string) ([]vsphereLibraryItem, error) {
+	var libraryIds []string
+	if err := getVsphereAPI(client, providerID, "api/content/library", &libraryIds); err != nil {
+		return nil, err
+	}
+	var items []vsphereLibraryItem
+	var libraryNames []string
+	found := false
+	for _, libraryId := range libraryIds {
+		var library struct {
+			Id   string `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := getVsphereAPI(client, providerID, fmt.Sprintf("api/content/library/%s", libraryId), &library); err != nil {
+			return nil, err
+		}
+		libraryNames = append(libraryNames, library.Name)
+		if libraryName != "" && library.Name != libraryName {
+			continue
+		}
+		found = true
+		var itemIds []string
+		if err := getVsphereAPI(client, providerID, fmt.Sprintf("api/content/library/item?library_id=%s", libraryId), &itemIds); err != nil {
+			return nil, err
+		}
+		for _, itemId := range itemIds {
+			var item struct {
+				Id   string `json:"id"`
+				Name string `json:"name"`
+				Type string `json:"type"`
+			}
+			if err := getVsphereAPI(client, providerID, fmt.Sprintf("api/content/library/item/%s", itemId), &item); err != nil {
+				return nil, err
+			}
+			if item.Id == "" {
+				item.Id = itemId
+			}
+			items = append(items, vsphereLibraryItem{Id: item.Id, Name: item.Name, Type: item.Type, Library: library.Name})
+		}
+	}
+	if libraryName != "" && !found {
+		return nil, fmt.Errorf("content library %q not found, existing content libraries: [%s]", libraryName, strings.Join(libraryNames, ", "))
+	}
+	return items, nil
+}
+
+// getVsphereAPI reads apiPath of the vCenter of the provider into result, through the vCenter API proxy of CM
+// /v1/spaces/default/providers/vsphere/{providerID}/api?path={apiPath}
+func getVsphereAPI(client *bigipnextsdk.BigipNextCM, providerID, apiPath string, result interface{}) error {
+	providerUrl := fmt.Sprintf("%s/vsphere/%s/api?path=%s", uriCMProviders, providerID, apiPath)
+	respData, err := client.GetCMRequest(providerUrl)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(respData, result)
+}
+
+// vsphereObjectID returns the ID of the object named name in objects, or an error listing the existing names
+func vsphereObjectID(objects []vsphereObject, kind, name string) (string, error) {
+	var names []string
+	for _, object := range objects {
+		if object.Name == name {
+			return object.Id, nil
+		}
+		names = append(names, object.Name)
+	}
+	return "", fmt.Errorf("%s %q not found, existing %ss: [%s]", kind, name, kind, strings.Join(names, ", "))
+}
+
+func vsphereString(item map[string]interface{}, key string) string {
+	value, _ := item[key].(string)
+	return value
+}
+
+func vsphereInt64(item map[string]interface{}, key string) int64 {
+	value, _ := item[key].(float64)
+	return int64(value)
+}
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMVsphereDatacenterDataSource{}
+)
+
+func NewCMVsphereDatacenterDataSource() datasource.DataSource {
+	return &CMVsphereDatacenterDataSource{}
+}
+
+// CMVsphereDatacenterDataSource defines the data source implementation.
+type CMVsphereDatacenterDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMVsphereDatacenterDataSourceModel describes the data source data model.
+type CMVsphereDatacenterDataSourceModel struct {
+	ProviderName           types.String              `tfsdk:"provider_name"`
+	DatacenterName         types.String              `tfsdk:"datacenter_name"`
+	Clusters               []CMVsphereClusterModel   `tfsdk:"clusters"`
+	Datastores             []CMVsphereDatastoreModel `tfsdk:"datastores"`
+	DatastoreMostFreeSpace types.String              `tfsdk:"datastore_most_free_space"`
+	Networks               []CMVsphereNetworkModel   `tfsdk:"networks"`
+	ID                     types.String              `tfsdk:"id"`
+}
+
+type CMVsphereClusterModel struct {
+	Id            types.String           `tfsdk:"id"`
+	Name          types.String           `tfsdk:"name"`
+	ResourcePools []CMVsphereObjectModel `tfsdk:"resource_pools"`
+}
+
+type CMVsphereDatastoreModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	FreeSpace types.Int64  `tfsdk:"free_space"`
+	Capacity  types.Int64  `tfsdk:"capacity"`
+}
+
+type CMVsphereNetworkModel struct {
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+func (d *CMVsphereDatacenterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_vsphere_datacenter"
+}
+
+func (d *CMVsphereDatacenterDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the clusters, resource pools, datastores and networks of a datacenter of the vCenter of a vSphere provider of BIG-IP Next Central Manager, to check or pick the `vsphere_provider` and `instance` names of `bigipnext_cm_deploy_vmware`.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the `VSPHERE` provider on BIG-IP Next Central Manager.",
+			},
+			"datacenter_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the datacenter in vCenter.",
+			},
+			"clusters": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of clusters of the datacenter.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "vCenter ID of the cluster.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the cluster.",
+						},
+						"resource_pools": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "List of resource pools of the cluster.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: cmVsphereObjectAttributes("resource pool"),
+							},
+						},
+					},
+				},
+			},
+			"datastores": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of datastores of the datacenter, the datastore with the most free space first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "vCenter ID of the datastore.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the datastore.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the datastore, such as `VMFS`, `NFS` or `VSAN`.",
+						},
+						"free_space": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Free space of the datastore, in bytes.",
+						},
+						"capacity": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Capacity of the datastore, in bytes.",
+						},
+					},
+				},
+			},
+			"datastore_most_free_space": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the datastore with the most free space, empty if the datacenter has no datastore.",
+			},
+			"networks": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of networks of the datacenter.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "vCenter ID of the network.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the network.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the network, such as `STANDARD_PORTGROUP` or `DISTRIBUTED_PORTGROUP`.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "vCenter ID of the datacenter.",
+			},
+		},
+	}
+}
+
+func (d *CMVsphereDatacenterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMVsphereDatacenterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMVsphereDatacenterDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	provider, err := findCMProvider(d.client, data.ProviderName.ValueString(), "VSPHERE")
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Lookup Error", err.Error())
+		return
+	}
+	datacenterID, err := getVsphereDatacenterID(d.client, provider.Id, data.DatacenterName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vSphere datacenter, got error: %s", err))
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[READ] vSphere datacenter %s: %s", data.DatacenterName.ValueString(), datacenterID))
+
+	clusters, err := getVsphereClusters(d.client, provider.Id, datacenterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vSphere clusters, got error: %s", err))
+		return
+	}
+	data.Clusters = []CMVsphereClusterModel{}
+	for _, cluster := range clusters {
+		resourcePools, err := getVsphereResourcePools(d.client, provider.Id, cluster.Id)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read resource pools of vSphere cluster %s, got error: %s", cluster.Name, err))
+			return
+		}
+		data.Clusters = append(data.Clusters, CMVsphereClusterModel{
+			Id:            types.StringValue(cluster.Id),
+			Name:          types.StringValue(cluster.Name),
+			ResourcePools: cmVsphereObjectModels(resourcePools),
+		})
+	}
+
+	datastores, err := getVsphereDatastores(d.client, provider.Id, datacenterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vSphere datastores, got error: %s", err))
+		return
+	}
+	sort.SliceStable(datastores, func(i, j int) bool {
+		return datastores[i].FreeSpace > datastores[j].FreeSpace
+	})
+	data.Datastores = []CMVsphereDatastoreModel{}
+	for _, datastore := range datastores {
+		data.Datastores = append(data.Datastores, CMVsphereDatastoreModel{
+			Id:        types.StringValue(datastore.Id),
+			Name:      types.StringValue(datastore.Name),
+			Type:      types.StringValue(datastore.Type),
+			FreeSpace: types.Int64Value(datastore.FreeSpace),
+			Capacity:  types.Int64Value(datastore.Capacity),
+		})
+	}
+	data.DatastoreMostFreeSpace = types.StringValue("")
+	if len(datastores) > 0 {
+		data.DatastoreMostFreeSpace = types.StringValue(datastores[0].Name)
+	}
+
+	networks, err := getVsphereNetworks(d.client, provider.Id, datacenterID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vSphere networks, got error: %s", err))
+		return
+	}
+	data.Networks = []CMVsphereNetworkModel{}
+	for _, network := range networks {
+		data.Networks = append(data.Networks, CMVsphereNetworkModel{
+			Id:   types.StringValue(network.Id),
+			Name: types.StringValue(network.Name),
+			Type: types.StringValue(network.Type),
+		})
+	}
+	data.ID = types.StringValue(datacenterID)
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMVsphereDatacentersDataSource{}
+)
+
+func NewCMVsphereDatacentersDataSource() datasource.DataSource {
+	return &CMVsphereDatacentersDataSource{}
+}
+
+// CMVsphereDatacentersDataSource defines the data source implementation.
+type CMVsphereDatacentersDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMVsphereDatacentersDataSourceModel describes the data source data model.
+type CMVsphereDatacentersDataSourceModel struct {
+	ProviderName types.String           `tfsdk:"provider_name"`
+	Datacenters  []CMVsphereObjectModel `tfsdk:"datacenters"`
+	ID           types.String           `tfsdk:"id"`
+}
+
+type CMVsphereObjectModel struct {
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (d *CMVsphereDatacentersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_vsphere_datacenters"
+}
+
+func (d *CMVsphereDatacentersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the datacenters of the vCenter of a vSphere provider of BIG-IP Next Central Manager.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the `VSPHERE` provider on BIG-IP Next Central Manager.",
+			},
+			"datacenters": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of datacenters of the vCenter.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: cmVsphereObjectAttributes("datacenter"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the provider.",
+			},
+		},
+	}
+}
+
+// cmVsphereObjectAttributes returns the id and name attributes of a vCenter object of the kind
+func cmVsphereObjectAttributes(kind string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: fmt.Sprintf("vCenter ID of the %s.", kind),
+		},
+		"name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: fmt.Sprintf("Name of the %s.", kind),
+		},
+	}
+}
+
+func (d *CMVsphereDatacentersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMVsphereDatacentersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMVsphereDatacentersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	provider, err := findCMProvider(d.client, data.ProviderName.ValueString(), "VSPHERE")
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Lookup Error", err.Error())
+		return
+	}
+	datacenters, err := getVsphereDatacenters(d.client, provider.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vSphere datacenters, got error: %s", err))
+		return
+	}
+	data.Datacenters = cmVsphereObjectModels(datacenters)
+	data.ID = types.StringValue(provider.Id)
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func cmVsphereObjectModels(objects []vsphereObject) []CMVsphereObjectModel {
+	models := []CMVsphereObjectModel{}
+	for _, object := range objects {
+		models = append(models, CMVsphereObjectModel{
+			Id:   types.StringValue(object.Id),
+			Name: types.StringValue(object.Name),
+		})
+	}
+	return models
+}
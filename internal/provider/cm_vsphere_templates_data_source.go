@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMVsphereTemplatesDataSource{}
+)
+
+func NewCMVsphereTemplatesDataSource() datasource.DataSource {
+	return &CMVsphereTemplatesDataSource{}
+}
+
+// CMVsphereTemplatesDataSource defines the data source implementation.
+type CMVsphereTemplatesDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMVsphereTemplatesDataSourceModel describes the data source data model.
+type CMVsphereTemplatesDataSourceModel struct {
+	ProviderName   types.String             `tfsdk:"provider_name"`
+	ContentLibrary types.String             `tfsdk:"content_library"`
+	Templates      []CMVsphereTemplateModel `tfsdk:"templates"`
+	ID             types.String             `tfsdk:"id"`
+}
+
+type CMVsphereTemplateModel struct {
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	ContentLibrary types.String `tfsdk:"content_library"`
+}
+
+func (d *CMVsphereTemplatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_vsphere_templates"
+}
+
+func (d *CMVsphereTemplatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the templates of the content libraries of the vCenter of a vSphere provider of BIG-IP Next Central Manager, to check or pick the `content_library` and `vm_template_name` of `bigipnext_cm_deploy_vmware`.",
+		Attributes: map[string]schema.Attribute{
+			"provider_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the `VSPHERE` provider on BIG-IP Next Central Manager.",
+			},
+			"content_library": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return the templates of this content library. The data source fails if the content library does not exist.",
+			},
+			"templates": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of templates of the content libraries.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "vCenter ID of the content library item.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the template.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of the content library item, such as `vm-template` or `ovf`.",
+						},
+						"content_library": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the content library of the template.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the provider.",
+			},
+		},
+	}
+}
+
+func (d *CMVsphereTemplatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMVsphereTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMVsphereTemplatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	provider, err := findCMProvider(d.client, data.ProviderName.ValueString(), "VSPHERE")
+	if err != nil {
+		resp.Diagnostics.AddError("Provider Lookup Error", err.Error())
+		return
+	}
+	items, err := getVsphereContentLibraryItems(d.client, provider.Id, data.ContentLibrary.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read vSphere content libraries, got error: %s", err))
+		return
+	}
+	data.Templates = []CMVsphereTemplateModel{}
+	for _, item := range items {
+		data.Templates = append(data.Templates, CMVsphereTemplateModel{
+			Id:             types.StringValue(item.Id),
+			Name:           types.StringValue(item.Name),
+			Type:           types.StringValue(item.Type),
+			ContentLibrary: types.StringValue(item.Library),
+		})
+	}
+	data.ID = types.StringValue(provider.Id)
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
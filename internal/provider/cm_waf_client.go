@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+const (
+	uriWAFPolicies         = "/v1/spaces/default/security/waf-policies"
+	uriWAFReports          = "/v1/spaces/default/security/waf/reports"
+	uriWAFEvents           = "/v1/spaces/default/security/waf/events"
+	uriWAFPolicyImport     = "/waf/v1/tasks/policy-import"
+	wafPolicyImportTimeout = 100
+)
+
+// wafPolicySuggestion is a policy builder learning suggestion of a WAF Security Policy
+type wafPolicySuggestion struct {
+	Id            string  `json:"id,omitempty"`
+	Type          string  `json:"type,omitempty"`
+	Action        string  `json:"action,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	LearningScore float64 `json:"learning_score,omitempty"`
+	Status        string  `json:"status,omitempty"`
+}
+
+// wafPolicyImportRequest is a WAF Security Policy imported to CM from the policy content
+type wafPolicyImportRequest struct {
+	PolicyName  string
+	Description string
+	Override    string
+	FileName    string
+	Content     []byte
+}
+
+// postWAFPolicyBody creates (POST) or updates (PUT) the WAF Security Policy from the JSON body of the policy
+// and returns the ID of the policy
+// /api/v1/spaces/default/security/waf-policies
+func postWAFPolicyBody(client *bigipnextsdk.BigipNextCM, op, id string, body []byte) (string, error) {
+	var respData []byte
+	var err error
+	if op == "PUT" {
+		respData, err = client.PutCMRequest(fmt.Sprintf("%s/%s", uriWAFPolicies, id), body)
+	} else {
+		respData, err = client.PostCMRequest(uriWAFPolicies, body)
+	}
+	if err != nil {
+		return "", err
+	}
+	var respInfo map[string]interface{}
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return "", err
+	}
+	policyId, ok := respInfo["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("WAF policy request failed with :%+v", respInfo)
+	}
+	return policyId, nil
+}
+
+// getWAFPolicyByName looks up a WAF Security Policy by name
+// /api/v1/spaces/default/security/waf-policies?filter=name+eq+'{name}'
+func getWAFPolicyByName(client *bigipnextsdk.BigipNextCM, name string) (map[string]interface{}, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s?filter=name+eq+'%s'", uriWAFPolicies, url.QueryEscape(name)))
+	if err != nil {
+		return nil, err
+	}
+	var respInfo struct {
+		Embedded struct {
+			Policies []map[string]interface{} `json:"policies"`
+		} `json:"_embedded"`
+	}
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return nil, err
+	}
+	for _, policy := range respInfo.Embedded.Policies {
+		if policy["name"] == name {
+			return policy, nil
+		}
+	}
+	return nil, fmt.Errorf("WAF policy %q was not found", name)
+}
+
+// getWAFPolicySuggestions lists the learning suggestions of the WAF Security Policy
+// https://clouddocs.f5.com/api/v1/spaces/default/security/waf-policies/{id}/suggestions
+func getWAFPolicySuggestions(client *bigipnextsdk.BigipNextCM, id string) ([]wafPolicySuggestion, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s/%s/suggestions", uriWAFPolicies, id))
+	if err != nil {
+		return nil, err
+	}
+	var respInfo struct {
+		Embedded struct {
+			Suggestions []wafPolicySuggestion `json:"suggestions"`
+		} `json:"_embedded"`
+	}
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return nil, err
+	}
+	return respInfo.Embedded.Suggestions, nil
+}
+
+// acceptWAFPolicySuggestions accepts learning suggestions of the WAF Security Policy, the accepted suggestions are
+// applied to the policy
+// https://clouddocs.f5.com/api/v1/spaces/default/security/waf-policies/{id}/suggestions/accept
+func acceptWAFPolicySuggestions(client *bigipnextsdk.BigipNextCM, id string, suggestionIds []string) ([]byte, error) {
+	body, err := json.Marshal(map[string][]string{"suggestion_ids": suggestionIds})
+	if err != nil {
+		return nil, err
+	}
+	return client.PostCMRequest(fmt.Sprintf("%s/%s/suggestions/accept", uriWAFPolicies, id), body)
+}
+
+// getWAFReportByName looks up the ID of a WAF Security Report by name
+// /api/v1/spaces/default/security/waf/reports?filter=name+eq+'{name}'
+func getWAFReportByName(client *bigipnextsdk.BigipNextCM, name string) (string, error) {
+	respData, err := client.GetCMRequest(fmt.Sprintf("%s?filter=name+eq+'%s'", uriWAFReports, url.QueryEscape(name)))
+	if err != nil {
+		return "", err
+	}
+	var respInfo struct {
+		Embedded struct {
+			Reports []struct {
+				Id   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"reports"`
+		} `json:"_embedded"`
+	}
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return "", err
+	}
+	for _, report := range respInfo.Embedded.Reports {
+		if report.Name == name {
+			return report.Id, nil
+		}
+	}
+	return "", fmt.Errorf("WAF report %q was not found", name)
+}
+
+// getWAFReportResults executes the WAF Security Report and returns its results, the top entries of each category
+// https://clouddocs.f5.com/api/v1/spaces/default/security/waf/reports/{id}/results
+func getWAFReportResults(client *bigipnextsdk.BigipNextCM, id string) ([]byte, error) {
+	return client.GetCMRequest(fmt.Sprintf("%s/%s/results", uriWAFReports, id))
+}
+
+// getWAFEvents lists the WAF security events matching the query, such as filter and limit
+// https://clouddocs.f5.com/api/v1/spaces/default/security/waf/events
+func getWAFEvents(client *bigipnextsdk.BigipNextCM, query url.Values) ([]byte, error) {
+	eventsUrl := uriWAFEvents
+	if len(query) > 0 {
+		eventsUrl = fmt.Sprintf("%s?%s", uriWAFEvents, query.Encode())
+	}
+	return client.GetCMRequest(eventsUrl)
+}
+
+// importWAFPolicy imports the WAF Security Policy content and waits for the import task to complete
+// /api/waf/v1/tasks/policy-import
+func importWAFPolicy(client *bigipnextsdk.BigipNextCM, config *wafPolicyImportRequest) (interface{}, error) {
+	fields := map[string]string{
+		"policy_name": config.PolicyName,
+		"description": config.Description,
+		"override":    config.Override,
+	}
+	respData, err := postCMMultipart(client, uriWAFPolicyImport, fields, config.FileName, bytes.NewReader(config.Content))
+	if err != nil {
+		return nil, err
+	}
+	// {"_links":{"self":{"href":"/api/waf/v1/tasks/policy-import/661f6053-7a8c-4f1d-9259-2f1c001490f4"}},"path":"/v1/tasks/policy-import/661f6053-7a8c-4f1d-9259-2f1c001490f4"}
+	var respInfo map[string]interface{}
+	if err = json.Unmarshal(respData, &respInfo); err != nil {
+		return nil, err
+	}
+	taskPath, ok := respInfo["path"].(string)
+	if !ok {
+		return nil, fmt.Errorf("WAF policy import failed with :%+v", respInfo)
+	}
+	pathList := strings.Split(taskPath, "/")
+	return client.PolicyImportStatus(pathList[len(pathList)-1], wafPolicyImportTimeout)
+}
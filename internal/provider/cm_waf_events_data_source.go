@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMWAFEventsDataSource{}
+)
+
+func NewCMWAFEventsDataSource() datasource.DataSource {
+	return &CMWAFEventsDataSource{}
+}
+
+// CMWAFEventsDataSource defines the data source implementation.
+type CMWAFEventsDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMWAFEventsDataSourceModel describes the data source data model.
+type CMWAFEventsDataSourceModel struct {
+	TimeWindowHours types.Int64       `tfsdk:"time_window_hours"`
+	StartTime       types.String      `tfsdk:"start_time"`
+	EndTime         types.String      `tfsdk:"end_time"`
+	PolicyNames     []types.String    `tfsdk:"policy_names"`
+	Limit           types.Int64       `tfsdk:"limit"`
+	Events          []CMWAFEventModel `tfsdk:"events"`
+	Total           types.Int64       `tfsdk:"total"`
+	ID              types.String      `tfsdk:"id"`
+}
+
+type CMWAFEventModel struct {
+	Id            types.String   `tfsdk:"id"`
+	Time          types.String   `tfsdk:"time"`
+	PolicyName    types.String   `tfsdk:"policy_name"`
+	SourceIp      types.String   `tfsdk:"source_ip"`
+	Method        types.String   `tfsdk:"method"`
+	Url           types.String   `tfsdk:"url"`
+	RequestStatus types.String   `tfsdk:"request_status"`
+	SupportId     types.String   `tfsdk:"support_id"`
+	Violations    []types.String `tfsdk:"violations"`
+	AttackTypes   []types.String `tfsdk:"attack_types"`
+}
+
+type wafEvents struct {
+	Embedded struct {
+		Events []struct {
+			Id            string   `json:"id"`
+			Time          string   `json:"time"`
+			PolicyName    string   `json:"policy_name"`
+			SourceIp      string   `json:"source_ip"`
+			Method        string   `json:"method"`
+			Url           string   `json:"url"`
+			RequestStatus string   `json:"request_status"`
+			SupportId     string   `json:"support_id"`
+			Violations    []string `json:"violations"`
+			AttackTypes   []string `json:"attack_types"`
+		} `json:"events"`
+	} `json:"_embedded"`
+	Total int64 `json:"total"`
+}
+
+func (d *CMWAFEventsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_waf_events"
+}
+
+func (d *CMWAFEventsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the WAF security events logged on BIG-IP Next Central Manager in a time window, optionally only for some WAF Policies.",
+		Attributes: map[string]schema.Attribute{
+			"time_window_hours": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Return the events of the last hours, up to `end_time`. Defaults to `24` when `start_time` is not set.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Return the events logged after this time, in RFC 3339 format such as `2024-05-01T00:00:00Z`. Conflicts with `time_window_hours`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("time_window_hours")),
+				},
+			},
+			"end_time": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Return the events logged before this time, in RFC 3339 format. Defaults to the current time.",
+			},
+			"policy_names": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only return the events of these WAF Policies.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of events returned, most recent first. Defaults to `100`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"events": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of security events matching the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the event.",
+						},
+						"time": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The timestamp of the request. In ISO 8601 format.",
+						},
+						"policy_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the WAF Policy which logged the event.",
+						},
+						"source_ip": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Client IP address of the request.",
+						},
+						"method": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "HTTP method of the request.",
+						},
+						"url": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "URL of the request.",
+						},
+						"request_status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Outcome of the request, such as `blocked`, `alerted` or `passed`.",
+						},
+						"support_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Support ID of the request.",
+						},
+						"violations": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Violations detected in the request.",
+						},
+						"attack_types": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Attack types detected in the request.",
+						},
+					},
+				},
+			},
+			"total": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Total number of events matching the filters, which can be more than the events returned.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this data source.",
+			},
+		},
+	}
+}
+
+func (d *CMWAFEventsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMWAFEventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMWAFEventsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	query, err := wafEventsQuery(&data, time.Now().UTC())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Attribute Value", err.Error())
+		return
+	}
+	respData, err := getWAFEvents(d.client, query)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read WAF events, got error: %s", err))
+		return
+	}
+	var events wafEvents
+	if err := json.Unmarshal(respData, &events); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse WAF events, got error: %s", err))
+		return
+	}
+	data.Events = []CMWAFEventModel{}
+	for _, event := range events.Embedded.Events {
+		eventModel := CMWAFEventModel{
+			Id:            types.StringValue(event.Id),
+			Time:          types.StringValue(event.Time),
+			PolicyName:    types.StringValue(event.PolicyName),
+			SourceIp:      types.StringValue(event.SourceIp),
+			Method:        types.StringValue(event.Method),
+			Url:           types.StringValue(event.Url),
+			RequestStatus: types.StringValue(event.RequestStatus),
+			SupportId:     types.StringValue(event.SupportId),
+			Violations:    []types.String{},
+			AttackTypes:   []types.String{},
+		}
+		for _, violation := range event.Violations {
+			eventModel.Violations = append(eventModel.Violations, types.StringValue(violation))
+		}
+		for _, attackType := range event.AttackTypes {
+			eventModel.AttackTypes = append(eventModel.AttackTypes, types.StringValue(attackType))
+		}
+		data.Events = append(data.Events, eventModel)
+	}
+	data.Total = types.Int64Value(events.Total)
+	if events.Total < int64(len(data.Events)) {
+		data.Total = types.Int64Value(int64(len(data.Events)))
+	}
+	data.ID = types.StringValue("cm_waf_events")
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// wafEventsQuery builds the filter and limit of the events query from the time window and policies of data,
+// relative to now
+func wafEventsQuery(data *CMWAFEventsDataSourceModel, now time.Time) (url.Values, error) {
+	end := now
+	if !data.EndTime.IsNull() {
+		var err error
+		if end, err = time.Parse(time.RFC3339, data.EndTime.ValueString()); err != nil {
+			return nil, fmt.Errorf("end_time must be in RFC 3339 format, got: %s", data.EndTime.ValueString())
+		}
+	}
+	var start time.Time
+	if !data.StartTime.IsNull() {
+		var err error
+		if start, err = time.Parse(time.RFC3339, data.StartTime.ValueString()); err != nil {
+			return nil, fmt.Errorf("start_time must be in RFC 3339 format, got: %s", data.StartTime.ValueString())
+		}
+	} else {
+		hours := int64(24)
+		if !data.TimeWindowHours.IsNull() {
+			hours = data.TimeWindowHours.ValueInt64()
+		}
+		start = end.Add(-time.Duration(hours) * time.Hour)
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("start of the time window %s must be before its end %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	limit := int64(100)
+	if !data.Limit.IsNull() {
+		limit = data.Limit.ValueInt64()
+	}
+
+	filter := fmt.Sprintf("time ge '%s' and time le '%s'", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	var policies []string
+	for _, policy := range data.PolicyNames {
+		policies = append(policies, fmt.Sprintf("policy_name eq '%s'", policy.ValueString()))
+	}
+	if len(policies) > 0 {
+		filter = fmt.Sprintf("%s and (%s)", filter, strings.Join(policies, " or "))
+	}
+	query := url.Values{}
+	query.Set("filter", filter)
+	query.Set("limit", strconv.FormatInt(limit, 10))
+	query.Set("sort", "time desc")
+	return query, nil
+}
@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource = &NextCMWAFPolicyAttachmentResource{}
+)
+
+func NewNextCMWAFPolicyAttachmentResource() resource.Resource {
+	return &NextCMWAFPolicyAttachmentResource{}
+}
+
+type NextCMWAFPolicyAttachmentResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type NextCMWAFPolicyAttachmentResourceModel struct {
+	PolicyName      types.String                      `tfsdk:"policy_name"`
+	Virtuals        []WafPolicyAttachmentVirtualModel `tfsdk:"virtuals"`
+	Timeout         types.Int64                       `tfsdk:"timeout"`
+	EnforcementMode types.String                      `tfsdk:"enforcement_mode"`
+	Instances       types.List                        `tfsdk:"instances"`
+	Id              types.String                      `tfsdk:"id"`
+}
+
+type WafPolicyAttachmentVirtualModel struct {
+	DocumentId  types.String `tfsdk:"document_id"`
+	Tenant      types.String `tfsdk:"tenant"`
+	Application types.String `tfsdk:"application"`
+	Service     types.String `tfsdk:"service"`
+}
+
+type WafPolicyAttachmentInstanceModel struct {
+	DocumentId       types.String `tfsdk:"document_id"`
+	InstanceId       types.String `tfsdk:"instance_id"`
+	Address          types.String `tfsdk:"address"`
+	DeploymentStatus types.String `tfsdk:"deployment_status"`
+	EnforcementMode  types.String `tfsdk:"enforcement_mode"`
+}
+
+var wafPolicyAttachmentInstanceAttrTypes = map[string]attr.Type{
+	"document_id":       types.StringType,
+	"instance_id":       types.StringType,
+	"address":           types.StringType,
+	"deployment_status": types.StringType,
+	"enforcement_mode":  types.StringType,
+}
+
+func (r *NextCMWAFPolicyAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_waf_policy_attachment"
+}
+
+func (r *NextCMWAFPolicyAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to attach a WAF Policy managed by BIG-IP Next Central Manager to the virtual servers of AS3 application services.\n\n" +
+			"The policy is set as `policyWAF` of each service in the application document on CM, and the document is deployed again to its instances. " +
+			"When doing `terraform destroy` the policy is removed from the services. Services detached or attached to another policy outside of Terraform are attached again on the next apply.\n\n" +
+			"~> **NOTE** An update of the `as3_json` of a `bigipnext_cm_as3_deploy` resource replaces the whole declaration of its document, " +
+			"which removes the policy from the services until the next apply of this resource attaches it again. " +
+			"To keep the policy attached across such updates, set `policyWAF` in `as3_json` instead of using this resource for that document.",
+		Attributes: map[string]schema.Attribute{
+			"policy_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the WAF Policy to attach, such as `bigipnext_cm_waf_policy.example.name`.",
+			},
+			"virtuals": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Application virtual servers the WAF Policy is attached to.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"document_id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "ID of the AS3 application service document on CM, such as `bigipnext_cm_as3_deploy.example.id`.",
+						},
+						"tenant": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the AS3 tenant.",
+						},
+						"application": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the AS3 application in the tenant.",
+						},
+						"service": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the `Service_HTTP` or `Service_HTTPS` virtual server in the application, such as `serviceMain`.",
+						},
+					},
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The number of seconds to wait for the deployment to each instance to finish.",
+				Default:             int64default.StaticInt64(900),
+			},
+			"enforcement_mode": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Enforcement mode of the WAF Policy, `blocking` or `transparent`.",
+			},
+			"instances": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Instances the application services are deployed to, and the state of the WAF Policy on each.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"document_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the AS3 application service document.",
+						},
+						"instance_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the instance.",
+						},
+						"address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Address of the instance.",
+						},
+						"deployment_status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Status of the latest deployment of the application service to the instance, such as `completed` or `failed`.",
+						},
+						"enforcement_mode": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Enforcement mode of the WAF Policy on the instance, `not-enforced` until the deployment is completed.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the WAF Policy",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NextCMWAFPolicyAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *NextCMWAFPolicyAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *NextCMWAFPolicyAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[CREATE] Attaching WAF Policy : %s", resCfg.PolicyName.ValueString()))
+	policy, err := getWAFPolicyByName(r.client, resCfg.PolicyName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read WAF Policy, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(r.setWafPolicy(ctx, resCfg.Virtuals, nil, resCfg.PolicyName.ValueString(), "", int(resCfg.Timeout.ValueInt64()))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resCfg.Id = types.StringValue(policy["id"].(string))
+	resp.Diagnostics.Append(r.wafPolicyAttachmentModeltoState(ctx, policy, resCfg)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *NextCMWAFPolicyAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *NextCMWAFPolicyAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	id := stateCfg.Id.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[READ] Reading WAF Policy attachment : %s", id))
+	wafData, err := r.client.GetWAFPolicyDetails(id)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Warn(ctx, fmt.Sprintf("[READ] WAF Policy %s not found, removing attachment from state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read WAF Policy, got error: %s", err))
+		return
+	}
+	policy := wafData.(map[string]interface{})
+	stateCfg.PolicyName = types.StringValue(policy["name"].(string))
+
+	// virtuals no longer attached to the policy are dropped from state, so the next apply attaches them again
+	var attached []WafPolicyAttachmentVirtualModel
+	for _, virtual := range stateCfg.Virtuals {
+		document, err := getAS3Document(r.client, virtual.DocumentId.ValueString())
+		if err != nil && strings.Contains(err.Error(), `"code":404`) {
+			continue
+		}
+		if err != nil { // coverage-ignore
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read application service %s, got error: %s", virtual.DocumentId.ValueString(), err))
+			return
+		}
+		service, err := wafPolicyAttachmentService(document.Declaration, virtual)
+		if err == nil && wafPolicyAttachmentName(service) == stateCfg.PolicyName.ValueString() {
+			attached = append(attached, virtual)
+		}
+	}
+	stateCfg.Virtuals = attached
+	resp.Diagnostics.Append(r.wafPolicyAttachmentModeltoState(ctx, policy, stateCfg)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *NextCMWAFPolicyAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg, stateCfg *NextCMWAFPolicyAttachmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[UPDATE] Updating WAF Policy attachment : %s", resCfg.PolicyName.ValueString()))
+	policy, err := getWAFPolicyByName(r.client, resCfg.PolicyName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read WAF Policy, got error: %s", err))
+		return
+	}
+	var removed []WafPolicyAttachmentVirtualModel
+	for _, virtual := range stateCfg.Virtuals {
+		if !slices.Contains(resCfg.Virtuals, virtual) {
+			removed = append(removed, virtual)
+		}
+	}
+	resp.Diagnostics.Append(r.setWafPolicy(ctx, resCfg.Virtuals, removed, resCfg.PolicyName.ValueString(), stateCfg.PolicyName.ValueString(), int(resCfg.Timeout.ValueInt64()))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resCfg.Id = types.StringValue(policy["id"].(string))
+	resp.Diagnostics.Append(r.wafPolicyAttachmentModeltoState(ctx, policy, resCfg)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
+}
+
+func (r *NextCMWAFPolicyAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *NextCMWAFPolicyAttachmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	tflog.Info(ctx, fmt.Sprintf("[DELETE] Detaching WAF Policy : %s", stateCfg.PolicyName.ValueString()))
+	resp.Diagnostics.Append(r.setWafPolicy(ctx, nil, stateCfg.Virtuals, "", stateCfg.PolicyName.ValueString(), int(stateCfg.Timeout.ValueInt64()))...)
+}
+
+// setWafPolicy attaches policyName to the attach virtuals and detaches detachName from the detach virtuals. On detach
+// only services still attached to detachName are changed. The changes are grouped per document, so each document is
+// updated once, and the deployment tasks created by the update are awaited.
+func (r *NextCMWAFPolicyAttachmentResource) setWafPolicy(ctx context.Context, attach, detach []WafPolicyAttachmentVirtualModel, policyName, detachName string, timeout int) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var documentIds []string
+	attachByDocument := make(map[string][]WafPolicyAttachmentVirtualModel)
+	detachByDocument := make(map[string][]WafPolicyAttachmentVirtualModel)
+	for _, virtual := range append(append([]WafPolicyAttachmentVirtualModel{}, attach...), detach...) {
+		documentId := virtual.DocumentId.ValueString()
+		if _, ok := attachByDocument[documentId]; !ok {
+			if _, ok := detachByDocument[documentId]; !ok {
+				documentIds = append(documentIds, documentId)
+			}
+		}
+		if slices.Contains(attach, virtual) {
+			attachByDocument[documentId] = append(attachByDocument[documentId], virtual)
+		} else {
+			detachByDocument[documentId] = append(detachByDocument[documentId], virtual)
+		}
+	}
+	for _, documentId := range documentIds {
+		document, err := getAS3Document(r.client, documentId)
+		if err != nil && len(attachByDocument[documentId]) == 0 && strings.Contains(err.Error(), `"code":404`) {
+			// the application service is already gone, there is nothing to detach
+			continue
+		}
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Failed to Read application service %s, got error: %s", documentId, err))
+			return diags
+		}
+		changed := false
+		for _, virtual := range detachByDocument[documentId] {
+			service, err := wafPolicyAttachmentService(document.Declaration, virtual)
+			if err == nil && wafPolicyAttachmentName(service) == detachName {
+				delete(service, "policyWAF")
+				changed = true
+			}
+		}
+		for _, virtual := range attachByDocument[documentId] {
+			service, err := wafPolicyAttachmentService(document.Declaration, virtual)
+			if err != nil {
+				diags.AddError("Virtual Not Found", err.Error())
+				return diags
+			}
+			if wafPolicyAttachmentName(service) != policyName {
+				service["policyWAF"] = map[string]interface{}{"cm": policyName}
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		declaration, err := json.Marshal(document.Declaration)
+		if err != nil { // coverage-ignore
+			diags.AddError("Client Error", fmt.Sprintf("Failed to encode application service %s, got error: %s", documentId, err))
+			return diags
+		}
+		tflog.Info(ctx, fmt.Sprintf("[setWafPolicy] Updating application service %s", documentId))
+		deployIds, err := updateAS3Document(r.client, documentId, declaration)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Failed to Update application service %s, got error: %s", documentId, err))
+			return diags
+		}
+		for _, deployId := range deployIds {
+			if err := waitAS3Deployment(r.client, documentId, deployId, timeout); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Failed to Deploy application service %s, deployment %s got error: %s", documentId, deployId, err))
+				return diags
+			}
+		}
+	}
+	return diags
+}
+
+// wafPolicyAttachmentModeltoState refreshes the enforcement mode and the state of the policy on each instance
+// the attached application services are deployed to
+func (r *NextCMWAFPolicyAttachmentResource) wafPolicyAttachmentModeltoState(ctx context.Context, policy map[string]interface{}, data *NextCMWAFPolicyAttachmentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	enforcementMode, _ := policy["enforcement_mode"].(string)
+	data.EnforcementMode = types.StringValue(enforcementMode)
+	seen := make(map[string]bool)
+	instances := []WafPolicyAttachmentInstanceModel{}
+	for _, virtual := range data.Virtuals {
+		documentId := virtual.DocumentId.ValueString()
+		if seen[documentId] {
+			continue
+		}
+		seen[documentId] = true
+		document, err := getAS3Document(r.client, documentId)
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("[wafPolicyAttachmentModeltoState] Failed to Read application service %s: %s", documentId, err))
+			continue
+		}
+		for _, deployment := range document.Deployments {
+			status, _, err := getAS3DeploymentStatus(r.client, documentId, deployment.Id)
+			if err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("[wafPolicyAttachmentModeltoState] Failed to Read deployment %s: %s", deployment.Id, err))
+			}
+			instanceMode := "not-enforced"
+			if status == "completed" {
+				instanceMode = enforcementMode
+			}
+			instances = append(instances, WafPolicyAttachmentInstanceModel{
+				DocumentId:       types.StringValue(documentId),
+				InstanceId:       types.StringValue(deployment.InstanceId),
+				Address:          types.StringValue(deployment.Target.Address),
+				DeploymentStatus: types.StringValue(status),
+				EnforcementMode:  types.StringValue(instanceMode),
+			})
+		}
+	}
+	data.Instances, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: wafPolicyAttachmentInstanceAttrTypes}, instances)
+	return diags
+}
+
+// wafPolicyAttachmentService returns the AS3 service of the virtual from the declaration of its application document
+func wafPolicyAttachmentService(declaration map[string]interface{}, virtual WafPolicyAttachmentVirtualModel) (map[string]interface{}, error) {
+	tenant, _ := declaration[virtual.Tenant.ValueString()].(map[string]interface{})
+	application, _ := tenant[virtual.Application.ValueString()].(map[string]interface{})
+	service, _ := application[virtual.Service.ValueString()].(map[string]interface{})
+	if class, _ := service["class"].(string); class != "Service_HTTP" && class != "Service_HTTPS" {
+		return nil, fmt.Errorf("no Service_HTTP or Service_HTTPS %s/%s/%s in application service %s", virtual.Tenant.ValueString(), virtual.Application.ValueString(), virtual.Service.ValueString(), virtual.DocumentId.ValueString())
+	}
+	return service, nil
+}
+
+// wafPolicyAttachmentName returns the name of the CM WAF Policy attached to the service
+func wafPolicyAttachmentName(service map[string]interface{}) string {
+	policyWAF, _ := service["policyWAF"].(map[string]interface{})
+	name, _ := policyWAF["cm"].(string)
+	return name
+}
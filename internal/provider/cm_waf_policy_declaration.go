@@ -0,0 +1,498 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+type WafPolicyUrlModel struct {
+	Name                  types.String `tfsdk:"name"`
+	Method                types.String `tfsdk:"method"`
+	Protocol              types.String `tfsdk:"protocol"`
+	Type                  types.String `tfsdk:"type"`
+	IsAllowed             types.Bool   `tfsdk:"is_allowed"`
+	AttackSignaturesCheck types.Bool   `tfsdk:"attack_signatures_check"`
+}
+
+type WafPolicyParameterModel struct {
+	Name                  types.String `tfsdk:"name"`
+	Type                  types.String `tfsdk:"type"`
+	ValueType             types.String `tfsdk:"value_type"`
+	DataType              types.String `tfsdk:"data_type"`
+	SensitiveParameter    types.Bool   `tfsdk:"sensitive_parameter"`
+	AttackSignaturesCheck types.Bool   `tfsdk:"attack_signatures_check"`
+}
+
+type WafPolicyFiletypeModel struct {
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Allowed types.Bool   `tfsdk:"allowed"`
+}
+
+type WafPolicySignatureSetModel struct {
+	Name  types.String `tfsdk:"name"`
+	Alarm types.Bool   `tfsdk:"alarm"`
+	Block types.Bool   `tfsdk:"block"`
+}
+
+type WafPolicySignatureModel struct {
+	SignatureId types.Int64 `tfsdk:"signature_id"`
+	Enabled     types.Bool  `tfsdk:"enabled"`
+}
+
+type WafPolicyWhitelistIpModel struct {
+	IpAddress     types.String `tfsdk:"ip_address"`
+	IpMask        types.String `tfsdk:"ip_mask"`
+	BlockRequests types.String `tfsdk:"block_requests"`
+	Description   types.String `tfsdk:"description"`
+}
+
+type WafPolicyCookieModel struct {
+	Name                  types.String `tfsdk:"name"`
+	Type                  types.String `tfsdk:"type"`
+	EnforcementType       types.String `tfsdk:"enforcement_type"`
+	AttackSignaturesCheck types.Bool   `tfsdk:"attack_signatures_check"`
+}
+
+type WafPolicyHeaderModel struct {
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	Mandatory       types.Bool   `tfsdk:"mandatory"`
+	CheckSignatures types.Bool   `tfsdk:"check_signatures"`
+}
+
+// wafPolicyEntityType is the schema of the type of a policy entity, which is either explicit or a wildcard
+func wafPolicyEntityType(entity string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             stringdefault.StaticString("explicit"),
+		MarkdownDescription: fmt.Sprintf("Whether the %s name is `explicit` or a `wildcard`. Default is `explicit`.", entity),
+		Validators: []validator.String{
+			stringvalidator.OneOf("explicit", "wildcard"),
+		},
+	}
+}
+
+func wafPolicyBool(description string, value bool) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(value),
+		MarkdownDescription: fmt.Sprintf("%s Default is `%t`.", description, value),
+	}
+}
+
+// wafPolicyEntities are the policy entities of the WAF policy declaration set from the resource
+type wafPolicyEntities struct {
+	Urls               []wafPolicyUrl              `json:"urls,omitempty"`
+	Parameters         []wafPolicyParameter        `json:"parameters,omitempty"`
+	Filetypes          []wafPolicyFiletype         `json:"filetypes,omitempty"`
+	SignatureSets      []wafPolicySignatureSet     `json:"signature-sets,omitempty"`
+	Signatures         []wafPolicySignature        `json:"signatures,omitempty"`
+	WhitelistIps       []wafPolicyWhitelistIp      `json:"whitelist-ips,omitempty"`
+	Cookies            []wafPolicyCookie           `json:"cookies,omitempty"`
+	Headers            []wafPolicyHeader           `json:"headers,omitempty"`
+	ServerTechnologies []wafPolicyServerTechnology `json:"server-technologies,omitempty"`
+}
+
+type wafPolicyUrl struct {
+	Name                  string `json:"name"`
+	Method                string `json:"method,omitempty"`
+	Protocol              string `json:"protocol,omitempty"`
+	Type                  string `json:"type,omitempty"`
+	IsAllowed             bool   `json:"isAllowed"`
+	AttackSignaturesCheck bool   `json:"attackSignaturesCheck"`
+}
+
+type wafPolicyParameter struct {
+	Name                  string `json:"name"`
+	Type                  string `json:"type,omitempty"`
+	ValueType             string `json:"valueType,omitempty"`
+	DataType              string `json:"dataType,omitempty"`
+	SensitiveParameter    bool   `json:"sensitiveParameter"`
+	AttackSignaturesCheck bool   `json:"attackSignaturesCheck"`
+}
+
+type wafPolicyFiletype struct {
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+	Allowed bool   `json:"allowed"`
+}
+
+type wafPolicySignatureSet struct {
+	Name  string `json:"name"`
+	Alarm bool   `json:"alarm"`
+	Block bool   `json:"block"`
+}
+
+type wafPolicySignature struct {
+	SignatureId int64 `json:"signatureId"`
+	Enabled     bool  `json:"enabled"`
+}
+
+type wafPolicyWhitelistIp struct {
+	IpAddress     string `json:"ipAddress"`
+	IpMask        string `json:"ipMask"`
+	BlockRequests string `json:"blockRequests,omitempty"`
+	Description   string `json:"description,omitempty"`
+}
+
+type wafPolicyCookie struct {
+	Name                  string `json:"name"`
+	Type                  string `json:"type,omitempty"`
+	EnforcementType       string `json:"enforcementType,omitempty"`
+	AttackSignaturesCheck bool   `json:"attackSignaturesCheck"`
+}
+
+type wafPolicyHeader struct {
+	Name            string `json:"name"`
+	Type            string `json:"type,omitempty"`
+	Mandatory       bool   `json:"mandatory"`
+	CheckSignatures bool   `json:"checkSignatures"`
+}
+
+type wafPolicyServerTechnology struct {
+	ServerTechnologyName string `json:"serverTechnologyName"`
+}
+
+// wafPolicyDeclarationEntities returns the policy entities of the resource
+func wafPolicyDeclarationEntities(ctx context.Context, data *NextCMWAFPolicyResourceModel) *wafPolicyEntities {
+	policy := &wafPolicyEntities{}
+	for _, url := range data.Urls {
+		policy.Urls = append(policy.Urls, wafPolicyUrl{
+			Name:                  url.Name.ValueString(),
+			Method:                url.Method.ValueString(),
+			Protocol:              url.Protocol.ValueString(),
+			Type:                  url.Type.ValueString(),
+			IsAllowed:             url.IsAllowed.ValueBool(),
+			AttackSignaturesCheck: url.AttackSignaturesCheck.ValueBool(),
+		})
+	}
+	for _, parameter := range data.Parameters {
+		policy.Parameters = append(policy.Parameters, wafPolicyParameter{
+			Name:                  parameter.Name.ValueString(),
+			Type:                  parameter.Type.ValueString(),
+			ValueType:             parameter.ValueType.ValueString(),
+			DataType:              parameter.DataType.ValueString(),
+			SensitiveParameter:    parameter.SensitiveParameter.ValueBool(),
+			AttackSignaturesCheck: parameter.AttackSignaturesCheck.ValueBool(),
+		})
+	}
+	for _, filetype := range data.Filetypes {
+		policy.Filetypes = append(policy.Filetypes, wafPolicyFiletype{
+			Name:    filetype.Name.ValueString(),
+			Type:    filetype.Type.ValueString(),
+			Allowed: filetype.Allowed.ValueBool(),
+		})
+	}
+	for _, signatureSet := range data.SignatureSets {
+		policy.SignatureSets = append(policy.SignatureSets, wafPolicySignatureSet{
+			Name:  signatureSet.Name.ValueString(),
+			Alarm: signatureSet.Alarm.ValueBool(),
+			Block: signatureSet.Block.ValueBool(),
+		})
+	}
+	for _, signature := range data.Signatures {
+		policy.Signatures = append(policy.Signatures, wafPolicySignature{
+			SignatureId: signature.SignatureId.ValueInt64(),
+			Enabled:     signature.Enabled.ValueBool(),
+		})
+	}
+	for _, whitelistIp := range data.WhitelistIps {
+		policy.WhitelistIps = append(policy.WhitelistIps, wafPolicyWhitelistIp{
+			IpAddress:     whitelistIp.IpAddress.ValueString(),
+			IpMask:        whitelistIp.IpMask.ValueString(),
+			BlockRequests: whitelistIp.BlockRequests.ValueString(),
+			Description:   whitelistIp.Description.ValueString(),
+		})
+	}
+	for _, cookie := range data.Cookies {
+		policy.Cookies = append(policy.Cookies, wafPolicyCookie{
+			Name:                  cookie.Name.ValueString(),
+			Type:                  cookie.Type.ValueString(),
+			EnforcementType:       cookie.EnforcementType.ValueString(),
+			AttackSignaturesCheck: cookie.AttackSignaturesCheck.ValueBool(),
+		})
+	}
+	for _, header := range data.Headers {
+		policy.Headers = append(policy.Headers, wafPolicyHeader{
+			Name:            header.Name.ValueString(),
+			Type:            header.Type.ValueString(),
+			Mandatory:       header.Mandatory.ValueBool(),
+			CheckSignatures: header.CheckSignatures.ValueBool(),
+		})
+	}
+	var serverTechnologies []string
+	_ = data.ServerTechnologies.ElementsAs(ctx, &serverTechnologies, false)
+	for _, serverTechnology := range serverTechnologies {
+		policy.ServerTechnologies = append(policy.ServerTechnologies, wafPolicyServerTechnology{ServerTechnologyName: serverTechnology})
+	}
+	return policy
+}
+
+// wafPolicyRequestBody returns the body of the WAF policy request, the policy entities are set in the declaration
+// of the request draft, which is merged onto the declaration_json so the attributes of the resource take precedence
+// over the declaration_json and the entities of the declaration_json are kept along the entities of the resource
+func wafPolicyRequestBody(draft *bigipnextsdk.CMWAFPolicyRequestDraft, entities *wafPolicyEntities, declarationJSON string) ([]byte, error) {
+	draftBody, err := json.Marshal(draft)
+	if err != nil { // coverage-ignore
+		return nil, err
+	}
+	entitiesBody, err := json.Marshal(entities)
+	if err != nil { // coverage-ignore
+		return nil, err
+	}
+	var request, policyEntities map[string]interface{}
+	_ = json.Unmarshal(draftBody, &request)
+	_ = json.Unmarshal(entitiesBody, &policyEntities)
+	policy := request["declaration"].(map[string]interface{})["policy"].(map[string]interface{})
+	for key, value := range policyEntities {
+		policy[key] = value
+	}
+	if declarationJSON != "" {
+		var declaration map[string]interface{}
+		if err := json.Unmarshal([]byte(declarationJSON), &declaration); err != nil {
+			return nil, fmt.Errorf("declaration_json is not a valid JSON object: %s", err)
+		}
+		request["declaration"] = mergeWafPolicyDeclaration(declaration, request["declaration"].(map[string]interface{}))
+	}
+	return json.Marshal(request)
+}
+
+// mergeWafPolicyDeclaration merges the override onto the base, objects are merged, lists of entities are merged by
+// the key of the entities and any other value of the override replaces the value of the base
+func mergeWafPolicyDeclaration(base, override map[string]interface{}) map[string]interface{} {
+	for key, value := range override {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if baseObject, ok := base[key].(map[string]interface{}); ok {
+				base[key] = mergeWafPolicyDeclaration(baseObject, value)
+				continue
+			}
+		case []interface{}:
+			if baseList, ok := base[key].([]interface{}); ok {
+				base[key] = mergeWafPolicyEntities(baseList, value)
+				continue
+			}
+		}
+		base[key] = value
+	}
+	return base
+}
+
+// mergeWafPolicyEntities merges the entities of the override onto the entities of the base, an entity of the
+// override replaces the entity of the base with the same key
+func mergeWafPolicyEntities(base, override []interface{}) []interface{} {
+	merged := append([]interface{}{}, base...)
+	for _, entity := range override {
+		key, ok := wafPolicyEntityKey(entity)
+		if !ok {
+			// not a list of entities, the list is replaced
+			return override
+		}
+		replaced := false
+		for i, baseEntity := range merged {
+			if baseKey, ok := wafPolicyEntityKey(baseEntity); ok && baseKey == key {
+				merged[i] = entity
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, entity)
+		}
+	}
+	return merged
+}
+
+// wafPolicyEntityKey returns the key identifying the entity of the policy, ok is false when the value is not an
+// entity
+func wafPolicyEntityKey(entity interface{}) (string, bool) {
+	entityMap, ok := entity.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	var key []string
+	for _, field := range []string{"name", "method", "protocol", "signatureId", "ipAddress", "serverTechnologyName"} {
+		if value, ok := entityMap[field]; ok {
+			key = append(key, fmt.Sprintf("%s=%v", field, value))
+		}
+	}
+	return strings.Join(key, ","), len(key) > 0
+}
+
+// wafPolicyEntries returns the entries of the policy entity of the declaration
+func wafPolicyEntries(policy map[string]interface{}, entity string) []map[string]interface{} {
+	var entries []map[string]interface{}
+	list, _ := policy[entity].([]interface{})
+	for _, entry := range list {
+		if entryMap, ok := entry.(map[string]interface{}); ok {
+			entries = append(entries, entryMap)
+		}
+	}
+	return entries
+}
+
+func wafPolicyString(entry map[string]interface{}, key string, value types.String) types.String {
+	if v, ok := entry[key].(string); ok {
+		return types.StringValue(v)
+	}
+	return value
+}
+
+func wafPolicyBoolValue(entry map[string]interface{}, key string, value types.Bool) types.Bool {
+	if v, ok := entry[key].(bool); ok {
+		return types.BoolValue(v)
+	}
+	return value
+}
+
+// wafPolicyDeclarationToState refreshes the policy entities in the state from the declaration of the policy. Only
+// the entities managed by the resource are refreshed, entities removed from the policy are removed from the state and
+// entities added to the policy outside of Terraform are ignored.
+func wafPolicyDeclarationToState(ctx context.Context, policy map[string]interface{}, data *NextCMWAFPolicyResourceModel) {
+	if data.Urls != nil {
+		urls := []WafPolicyUrlModel{}
+		for _, url := range data.Urls {
+			for _, entry := range wafPolicyEntries(policy, "urls") {
+				if entry["name"] == url.Name.ValueString() && wafPolicyString(entry, "protocol", url.Protocol).Equal(url.Protocol) &&
+					wafPolicyString(entry, "method", url.Method).Equal(url.Method) {
+					url.Type = wafPolicyString(entry, "type", url.Type)
+					url.IsAllowed = wafPolicyBoolValue(entry, "isAllowed", url.IsAllowed)
+					url.AttackSignaturesCheck = wafPolicyBoolValue(entry, "attackSignaturesCheck", url.AttackSignaturesCheck)
+					urls = append(urls, url)
+					break
+				}
+			}
+		}
+		data.Urls = urls
+	}
+	if data.Parameters != nil {
+		parameters := []WafPolicyParameterModel{}
+		for _, parameter := range data.Parameters {
+			for _, entry := range wafPolicyEntries(policy, "parameters") {
+				if entry["name"] == parameter.Name.ValueString() {
+					parameter.Type = wafPolicyString(entry, "type", parameter.Type)
+					parameter.ValueType = wafPolicyString(entry, "valueType", parameter.ValueType)
+					parameter.DataType = wafPolicyString(entry, "dataType", parameter.DataType)
+					parameter.SensitiveParameter = wafPolicyBoolValue(entry, "sensitiveParameter", parameter.SensitiveParameter)
+					parameter.AttackSignaturesCheck = wafPolicyBoolValue(entry, "attackSignaturesCheck", parameter.AttackSignaturesCheck)
+					parameters = append(parameters, parameter)
+					break
+				}
+			}
+		}
+		data.Parameters = parameters
+	}
+	if data.Filetypes != nil {
+		filetypes := []WafPolicyFiletypeModel{}
+		for _, filetype := range data.Filetypes {
+			for _, entry := range wafPolicyEntries(policy, "filetypes") {
+				if entry["name"] == filetype.Name.ValueString() {
+					filetype.Type = wafPolicyString(entry, "type", filetype.Type)
+					filetype.Allowed = wafPolicyBoolValue(entry, "allowed", filetype.Allowed)
+					filetypes = append(filetypes, filetype)
+					break
+				}
+			}
+		}
+		data.Filetypes = filetypes
+	}
+	if data.SignatureSets != nil {
+		signatureSets := []WafPolicySignatureSetModel{}
+		for _, signatureSet := range data.SignatureSets {
+			for _, entry := range wafPolicyEntries(policy, "signature-sets") {
+				if entry["name"] == signatureSet.Name.ValueString() {
+					signatureSet.Alarm = wafPolicyBoolValue(entry, "alarm", signatureSet.Alarm)
+					signatureSet.Block = wafPolicyBoolValue(entry, "block", signatureSet.Block)
+					signatureSets = append(signatureSets, signatureSet)
+					break
+				}
+			}
+		}
+		data.SignatureSets = signatureSets
+	}
+	if data.Signatures != nil {
+		signatures := []WafPolicySignatureModel{}
+		for _, signature := range data.Signatures {
+			for _, entry := range wafPolicyEntries(policy, "signatures") {
+				if id, ok := entry["signatureId"].(float64); ok && int64(id) == signature.SignatureId.ValueInt64() {
+					signature.Enabled = wafPolicyBoolValue(entry, "enabled", signature.Enabled)
+					signatures = append(signatures, signature)
+					break
+				}
+			}
+		}
+		data.Signatures = signatures
+	}
+	if data.WhitelistIps != nil {
+		whitelistIps := []WafPolicyWhitelistIpModel{}
+		for _, whitelistIp := range data.WhitelistIps {
+			for _, entry := range wafPolicyEntries(policy, "whitelist-ips") {
+				if entry["ipAddress"] == whitelistIp.IpAddress.ValueString() {
+					whitelistIp.IpMask = wafPolicyString(entry, "ipMask", whitelistIp.IpMask)
+					whitelistIp.BlockRequests = wafPolicyString(entry, "blockRequests", whitelistIp.BlockRequests)
+					whitelistIp.Description = wafPolicyString(entry, "description", whitelistIp.Description)
+					whitelistIps = append(whitelistIps, whitelistIp)
+					break
+				}
+			}
+		}
+		data.WhitelistIps = whitelistIps
+	}
+	if data.Cookies != nil {
+		cookies := []WafPolicyCookieModel{}
+		for _, cookie := range data.Cookies {
+			for _, entry := range wafPolicyEntries(policy, "cookies") {
+				if entry["name"] == cookie.Name.ValueString() {
+					cookie.Type = wafPolicyString(entry, "type", cookie.Type)
+					cookie.EnforcementType = wafPolicyString(entry, "enforcementType", cookie.EnforcementType)
+					cookie.AttackSignaturesCheck = wafPolicyBoolValue(entry, "attackSignaturesCheck", cookie.AttackSignaturesCheck)
+					cookies = append(cookies, cookie)
+					break
+				}
+			}
+		}
+		data.Cookies = cookies
+	}
+	if data.Headers != nil {
+		headers := []WafPolicyHeaderModel{}
+		for _, header := range data.Headers {
+			for _, entry := range wafPolicyEntries(policy, "headers") {
+				if entry["name"] == header.Name.ValueString() {
+					header.Type = wafPolicyString(entry, "type", header.Type)
+					header.Mandatory = wafPolicyBoolValue(entry, "mandatory", header.Mandatory)
+					header.CheckSignatures = wafPolicyBoolValue(entry, "checkSignatures", header.CheckSignatures)
+					headers = append(headers, header)
+					break
+				}
+			}
+		}
+		data.Headers = headers
+	}
+	if !data.ServerTechnologies.IsNull() {
+		serverTechnologies := []string{}
+		var configured []string
+		_ = data.ServerTechnologies.ElementsAs(ctx, &configured, false)
+		for _, name := range configured {
+			for _, entry := range wafPolicyEntries(policy, "server-technologies") {
+				if entry["serverTechnologyName"] == name {
+					serverTechnologies = append(serverTechnologies, name)
+					break
+				}
+			}
+		}
+		data.ServerTechnologies, _ = types.ListValueFrom(ctx, types.StringType, serverTechnologies)
+	}
+}
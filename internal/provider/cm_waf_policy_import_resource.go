@@ -1,9 +1,22 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -21,6 +34,7 @@ import (
 var (
 	_ resource.Resource                = &NextCMWAFPolicyImportResource{}
 	_ resource.ResourceWithImportState = &NextCMWAFPolicyImportResource{}
+	_ resource.ResourceWithModifyPlan  = &NextCMWAFPolicyImportResource{}
 	// mutex sync.Mutex
 )
 
@@ -36,18 +50,26 @@ type NextCMWAFPolicyImportResourceModel struct {
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
 	FilePath    types.String `tfsdk:"file_path"`
+	Content     types.String `tfsdk:"content"`
+	Url         types.String `tfsdk:"url"`
 	FileMd5     types.String `tfsdk:"file_md5"`
+	ContentHash types.String `tfsdk:"content_hash"`
+	ExportPath  types.String `tfsdk:"export_path"`
 	Override    types.String `tfsdk:"override"`
 	Id          types.String `tfsdk:"id"`
 }
 
+// wafPolicyIgnoredSourceKeys are policy keys which CM sets from the resource attributes on import, so they are
+// not compared when checking the policy on CM against the source
+var wafPolicyIgnoredSourceKeys = []string{"name", "fullPath", "description"}
+
 func (r *NextCMWAFPolicyImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_cm_waf_policy_import"
 }
 
 func (r *NextCMWAFPolicyImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used to Import WAF Policy using policy json ( available local system disk )",
+		MarkdownDescription: "Resource used to Import WAF Policy from a policy JSON or XML file, inline content or an HTTP(S) URL",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -63,12 +85,38 @@ func (r *NextCMWAFPolicyImportResource) Schema(ctx context.Context, req resource
 				},
 			},
 			"file_path": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Specifies WAF Policy Json file path ( available on local system disk path )",
+				Optional:            true,
+				MarkdownDescription: "Specifies WAF Policy JSON or XML file path ( available on local system disk path ). Exactly one of `file_path`, `content` or `url` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("file_path"), path.MatchRoot("content"), path.MatchRoot("url")),
+				},
+			},
+			"content": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Specifies the WAF Policy as inline JSON or XML content.",
+			},
+			"url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Specifies an HTTP(S) URL the WAF Policy JSON or XML is downloaded from. The URL is fetched when planning and applying.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^https?://`),
+						"The url must start with http:// or https://.",
+					),
+				},
 			},
 			"file_md5": schema.StringAttribute{
-				Required:            true,
+				Optional:            true,
 				MarkdownDescription: "MD5 value for WAF Policy Json file ( available on local system disk path )",
+				DeprecationMessage:  "Changes to the policy source are detected through `content_hash`, `file_md5` is no longer needed and will be removed in a future release.",
+			},
+			"content_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA256 hash of the WAF Policy source. Set to an empty value when the policy on CM no longer matches the source, so the next apply imports it again.",
+			},
+			"export_path": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Local file path the current WAF Policy declaration is exported to as JSON after each import and refresh.",
 			},
 			"description": schema.StringAttribute{
 				Optional:            true,
@@ -108,15 +156,31 @@ func (r *NextCMWAFPolicyImportResource) Create(ctx context.Context, req resource
 	if resp.Diagnostics.HasError() { // coverage-ignore
 		return
 	}
-	reqDraft := getCMWAFPolicyImportConfig(ctx, resCfg)
+	reqDraft, err := getCMWAFPolicyImportConfig(ctx, resCfg)
+	if err != nil {
+		resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Read WAF Policy source, got error: %s", err))
+		return
+	}
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] CM WAF Policy Import config : %+v\n", reqDraft))
 
-	id, err := r.client.PolicyImport(reqDraft)
+	id, err := importWAFPolicy(r.client, reqDraft)
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Import WAF Policy, got error: %s", err))
 		return
 	}
 	resCfg.Id = types.StringValue(id.(map[string]interface{})["policy_id"].(string))
+	resCfg.ContentHash = types.StringValue(wafPolicySourceHash(reqDraft.Content))
+	if !resCfg.ExportPath.IsNull() {
+		wafData, err := r.client.GetWAFPolicyDetails(resCfg.Id.ValueString())
+		if err != nil { // coverage-ignore
+			resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Read WAF Policy, got error: %s", err))
+			return
+		}
+		if err := exportWafPolicy(wafData, resCfg.ExportPath.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Export WAF Policy, got error: %s", err))
+			return
+		}
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
 
 }
@@ -130,11 +194,27 @@ func (r *NextCMWAFPolicyImportResource) Read(ctx context.Context, req resource.R
 	id := stateCfg.Id.ValueString()
 	tflog.Info(ctx, fmt.Sprintf("[READ] Reading WAF Policy : %s", id))
 	wafData, err := r.client.GetWAFPolicyDetails(id)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Warn(ctx, fmt.Sprintf("[READ] WAF Policy %s not found, removing from state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Read WAF Policy, got error: %s", err))
 		return
 	}
 	r.WafPolicyModeltoState(ctx, wafData, stateCfg)
+	if !stateCfg.ExportPath.IsNull() {
+		if err := exportWafPolicy(wafData, stateCfg.ExportPath.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Export WAF Policy, got error: %s", err))
+			return
+		}
+	}
+	// the source is only compared when it can still be read, a missing file or unreachable URL is reported on plan
+	if content, _, err := readWafPolicySource(stateCfg); err == nil && !wafPolicyMatchesSource(wafData, content) {
+		tflog.Warn(ctx, fmt.Sprintf("[READ] WAF Policy %s no longer matches its source, it will be imported again", id))
+		stateCfg.ContentHash = types.StringValue("")
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
 }
 
@@ -151,15 +231,31 @@ func (r *NextCMWAFPolicyImportResource) Update(ctx context.Context, req resource
 
 	tflog.Info(ctx, fmt.Sprintf("[UPDATE] id:%+v\n", resCfg.Id.ValueString()))
 
-	reqDraft := getCMWAFPolicyImportConfig(ctx, resCfg)
+	reqDraft, err := getCMWAFPolicyImportConfig(ctx, resCfg)
+	if err != nil {
+		resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Read WAF Policy source, got error: %s", err))
+		return
+	}
 	tflog.Info(ctx, fmt.Sprintf("[UPDATE] CM WAF Policy Import config : %+v\n", reqDraft))
 	reqDraft.Override = "true"
-	id, err := r.client.PolicyImport(reqDraft)
+	id, err := importWAFPolicy(r.client, reqDraft)
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Import WAF Policy, got error: %s", err))
 		return
 	}
 	resCfg.Id = types.StringValue(id.(map[string]interface{})["policy_id"].(string))
+	resCfg.ContentHash = types.StringValue(wafPolicySourceHash(reqDraft.Content))
+	if !resCfg.ExportPath.IsNull() {
+		wafData, err := r.client.GetWAFPolicyDetails(resCfg.Id.ValueString())
+		if err != nil { // coverage-ignore
+			resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Read WAF Policy, got error: %s", err))
+			return
+		}
+		if err := exportWafPolicy(wafData, resCfg.ExportPath.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Export WAF Policy, got error: %s", err))
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
 }
@@ -184,18 +280,170 @@ func (r *NextCMWAFPolicyImportResource) Delete(ctx context.Context, req resource
 	stateCfg.Id = types.StringValue("")
 }
 
+func (r *NextCMWAFPolicyImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan *NextCMWAFPolicyImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	if plan.FilePath.IsUnknown() || plan.Content.IsUnknown() || plan.Url.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), types.StringUnknown())...)
+		return
+	}
+	content, _, err := readWafPolicySource(plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read WAF Policy Source", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), wafPolicySourceHash(content))...)
+}
+
+// ImportState accepts either the ID or the name of the WAF Policy
 func (r *NextCMWAFPolicyImportResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	id, err := resolveWafPolicyID(r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Import WAF Policy %s, got error: %s", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
-func getCMWAFPolicyImportConfig(ctx context.Context, data *NextCMWAFPolicyImportResourceModel) *bigipnextsdk.PolicyimportReqObj {
-	var policyImportReqObj bigipnextsdk.PolicyimportReqObj
+func getCMWAFPolicyImportConfig(ctx context.Context, data *NextCMWAFPolicyImportResourceModel) (*wafPolicyImportRequest, error) {
+	var policyImportReqObj wafPolicyImportRequest
+	content, fileName, err := readWafPolicySource(data)
+	if err != nil {
+		return nil, err
+	}
 	policyImportReqObj.PolicyName = data.Name.ValueString()
-	policyImportReqObj.FilePath = data.FilePath.ValueString()
+	policyImportReqObj.FileName = fileName
+	policyImportReqObj.Content = content
 	policyImportReqObj.Description = data.Description.ValueString()
 	policyImportReqObj.Override = data.Override.ValueString()
-	tflog.Info(ctx, fmt.Sprintf("[getCMWAFPolicyImportConfig] policyImportReqObj:%+v\n", policyImportReqObj))
-	return &policyImportReqObj
+	tflog.Info(ctx, fmt.Sprintf("[getCMWAFPolicyImportConfig] policy:%s file:%s override:%s\n", policyImportReqObj.PolicyName, fileName, policyImportReqObj.Override))
+	return &policyImportReqObj, nil
+}
+
+// readWafPolicySource returns the policy from file_path, content or url along with the file name sent to CM
+func readWafPolicySource(data *NextCMWAFPolicyImportResourceModel) ([]byte, string, error) {
+	switch {
+	case !data.FilePath.IsNull():
+		content, err := os.ReadFile(data.FilePath.ValueString())
+		if err != nil {
+			return nil, "", err
+		}
+		return content, filepath.Base(data.FilePath.ValueString()), nil
+	case !data.Content.IsNull():
+		content := []byte(data.Content.ValueString())
+		return content, "policy" + wafPolicySourceExtension(content), nil
+	case !data.Url.IsNull():
+		client := &http.Client{Timeout: 60 * time.Second}
+		httpResp, err := client.Get(data.Url.ValueString())
+		if err != nil {
+			return nil, "", err
+		}
+		defer httpResp.Body.Close()
+		if httpResp.StatusCode != http.StatusOK {
+			return nil, "", fmt.Errorf("unexpected status %s downloading %s", httpResp.Status, data.Url.ValueString())
+		}
+		content, err := io.ReadAll(httpResp.Body)
+		if err != nil { // coverage-ignore
+			return nil, "", err
+		}
+		fileName := "policy" + wafPolicySourceExtension(content)
+		if u, err := url.Parse(data.Url.ValueString()); err == nil && filepath.Ext(u.Path) != "" {
+			fileName = filepath.Base(u.Path)
+		}
+		return content, fileName, nil
+	}
+	return nil, "", fmt.Errorf("one of file_path, content or url must be set")
+}
+
+func wafPolicySourceExtension(content []byte) string {
+	if bytes.HasPrefix(bytes.TrimSpace(content), []byte("<")) {
+		return ".xml"
+	}
+	return ".json"
+}
+
+func wafPolicySourceHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// exportWafPolicy writes the declaration of the WAF Policy read from CM to exportPath
+func exportWafPolicy(respData interface{}, exportPath string) error {
+	declaration, ok := respData.(map[string]interface{})["declaration"]
+	if !ok { // coverage-ignore
+		return fmt.Errorf("WAF policy has no declaration")
+	}
+	data, err := json.MarshalIndent(declaration, "", "  ")
+	if err != nil { // coverage-ignore
+		return err
+	}
+	return os.WriteFile(exportPath, append(data, '\n'), 0o600)
+}
+
+// wafPolicyMatchesSource reports whether every setting of a JSON policy source is still present in the policy
+// declaration on CM. XML sources are converted by CM and cannot be compared, so they always match.
+func wafPolicyMatchesSource(respData interface{}, content []byte) bool {
+	var source map[string]interface{}
+	if err := json.Unmarshal(content, &source); err != nil {
+		return true
+	}
+	sourcePolicy, ok := source["policy"].(map[string]interface{})
+	if !ok {
+		return true
+	}
+	declaration, _ := respData.(map[string]interface{})["declaration"].(map[string]interface{})
+	policy, _ := declaration["policy"].(map[string]interface{})
+	for key, value := range sourcePolicy {
+		if slices.Contains(wafPolicyIgnoredSourceKeys, key) {
+			continue
+		}
+		if !jsonSubset(value, policy[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonSubset reports whether src is contained in dst: objects by key, lists element by element in any order
+func jsonSubset(src, dst interface{}) bool {
+	switch srcValue := src.(type) {
+	case map[string]interface{}:
+		dstValue, ok := dst.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range srcValue {
+			if !jsonSubset(value, dstValue[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		dstValue, ok := dst.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, value := range srcValue {
+			found := false
+			for _, candidate := range dstValue {
+				if jsonSubset(value, candidate) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(src, dst)
 }
 
 func (r *NextCMWAFPolicyImportResource) WafPolicyModeltoState(ctx context.Context, respData interface{}, data *NextCMWAFPolicyImportResourceModel) {
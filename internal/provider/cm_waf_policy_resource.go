@@ -7,10 +7,12 @@ import (
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -20,8 +22,9 @@ import (
 )
 
 var (
-	_ resource.Resource                = &NextCMWAFPolicyResource{}
-	_ resource.ResourceWithImportState = &NextCMWAFPolicyResource{}
+	_ resource.Resource                   = &NextCMWAFPolicyResource{}
+	_ resource.ResourceWithImportState    = &NextCMWAFPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &NextCMWAFPolicyResource{}
 	// mutex sync.Mutex
 )
 
@@ -34,17 +37,27 @@ type NextCMWAFPolicyResource struct {
 }
 
 type NextCMWAFPolicyResourceModel struct {
-	Name                types.String `tfsdk:"name"`
-	Description         types.String `tfsdk:"description"`
-	Tags                types.List   `tfsdk:"tags"`
-	EnforecementMode    types.String `tfsdk:"enforcement_mode"`
-	ApplicationLanguage types.String `tfsdk:"application_language"`
-	TemplateName        types.String `tfsdk:"template_name"`
-	BotDefense          types.Object `tfsdk:"bot_defense"`
-	IpIntelligence      types.Object `tfsdk:"ip_intelligence"`
-	DosProtection       types.Object `tfsdk:"dos_protection"`
-	BlockingSettings    types.Object `tfsdk:"blocking_settings"`
-	Id                  types.String `tfsdk:"id"`
+	Name                types.String                 `tfsdk:"name"`
+	Description         types.String                 `tfsdk:"description"`
+	Tags                types.List                   `tfsdk:"tags"`
+	EnforecementMode    types.String                 `tfsdk:"enforcement_mode"`
+	ApplicationLanguage types.String                 `tfsdk:"application_language"`
+	TemplateName        types.String                 `tfsdk:"template_name"`
+	BotDefense          types.Object                 `tfsdk:"bot_defense"`
+	IpIntelligence      types.Object                 `tfsdk:"ip_intelligence"`
+	DosProtection       types.Object                 `tfsdk:"dos_protection"`
+	BlockingSettings    types.Object                 `tfsdk:"blocking_settings"`
+	DeclarationJSON     types.String                 `tfsdk:"declaration_json"`
+	Urls                []WafPolicyUrlModel          `tfsdk:"urls"`
+	Parameters          []WafPolicyParameterModel    `tfsdk:"parameters"`
+	Filetypes           []WafPolicyFiletypeModel     `tfsdk:"filetypes"`
+	SignatureSets       []WafPolicySignatureSetModel `tfsdk:"signature_sets"`
+	Signatures          []WafPolicySignatureModel    `tfsdk:"signatures"`
+	WhitelistIps        []WafPolicyWhitelistIpModel  `tfsdk:"whitelist_ips"`
+	Cookies             []WafPolicyCookieModel       `tfsdk:"cookies"`
+	Headers             []WafPolicyHeaderModel       `tfsdk:"headers"`
+	ServerTechnologies  types.List                   `tfsdk:"server_technologies"`
+	Id                  types.String                 `tfsdk:"id"`
 }
 
 type BotDefenseModel struct {
@@ -76,7 +89,9 @@ func (r *NextCMWAFPolicyResource) Metadata(ctx context.Context, req resource.Met
 
 func (r *NextCMWAFPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used to manage(CRUD) WAF Policy resources onto BIG-IP Next CM.",
+		MarkdownDescription: "Resource used to manage(CRUD) WAF Policy resources onto BIG-IP Next CM.\n\n" +
+			"The URLs, parameters, file types, signature sets, signature overrides, trusted IP addresses, cookies, headers and server technologies of the policy are managed by the nested attributes, " +
+			"any other setting of the policy declaration by `declaration_json`.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -173,6 +188,192 @@ func (r *NextCMWAFPolicyResource) Schema(ctx context.Context, req resource.Schem
 					},
 				},
 			},
+			"urls": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "URLs of the policy.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Path of the URL, such as `/index.html`.",
+						},
+						"method": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("*"),
+							MarkdownDescription: "HTTP method of the URL. Default is `*`, any method.",
+						},
+						"protocol": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("http"),
+							MarkdownDescription: "Protocol of the URL, `http` or `https`. Default is `http`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("http", "https"),
+							},
+						},
+						"type":                    wafPolicyEntityType("URL"),
+						"is_allowed":              wafPolicyBool("Whether the URL is allowed.", true),
+						"attack_signatures_check": wafPolicyBool("Whether requests to the URL are checked for attack signatures.", true),
+					},
+				},
+			},
+			"parameters": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Parameters of the policy.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the parameter.",
+						},
+						"type": wafPolicyEntityType("parameter"),
+						"value_type": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("user-input"),
+							MarkdownDescription: "Type of the value of the parameter, such as `user-input`, `static-content` or `ignore`. Default is `user-input`.",
+						},
+						"data_type": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("alpha-numeric"),
+							MarkdownDescription: "Data type of a `user-input` value, such as `alpha-numeric`, `integer` or `email`. Default is `alpha-numeric`.",
+						},
+						"sensitive_parameter":     wafPolicyBool("Whether the value of the parameter is masked in the logs.", false),
+						"attack_signatures_check": wafPolicyBool("Whether the value of the parameter is checked for attack signatures.", true),
+					},
+				},
+			},
+			"filetypes": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "File types of the policy.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "File extension, such as `php`.",
+						},
+						"type":    wafPolicyEntityType("file type"),
+						"allowed": wafPolicyBool("Whether the file type is allowed.", true),
+					},
+				},
+			},
+			"signature_sets": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Attack signature sets of the policy.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the signature set, such as `High Accuracy Signatures`.",
+						},
+						"alarm": wafPolicyBool("Whether a request matching the signature set is logged.", true),
+						"block": wafPolicyBool("Whether a request matching the signature set is blocked.", true),
+					},
+				},
+			},
+			"signatures": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Overrides of attack signatures of the policy.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"signature_id": schema.Int64Attribute{
+							Required:            true,
+							MarkdownDescription: "ID of the attack signature.",
+						},
+						"enabled": schema.BoolAttribute{
+							Required:            true,
+							MarkdownDescription: "Whether the attack signature is enabled.",
+						},
+					},
+				},
+			},
+			"whitelist_ips": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Trusted IP addresses of the policy.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ip_address": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "IP address.",
+						},
+						"ip_mask": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("255.255.255.255"),
+							MarkdownDescription: "Netmask of the IP address. Default is `255.255.255.255`.",
+						},
+						"block_requests": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("policy-default"),
+							MarkdownDescription: "Whether requests from the IP address are blocked, `policy-default`, `never` or `always`. Default is `policy-default`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("policy-default", "never", "always"),
+							},
+						},
+						"description": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(""),
+							MarkdownDescription: "Description of the IP address.",
+						},
+					},
+				},
+			},
+			"cookies": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Cookies of the policy.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the cookie.",
+						},
+						"type": wafPolicyEntityType("cookie"),
+						"enforcement_type": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("allow"),
+							MarkdownDescription: "Whether the cookie may be changed by the client, `allow`, or is enforced, `enforce`. Default is `allow`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("allow", "enforce"),
+							},
+						},
+						"attack_signatures_check": wafPolicyBool("Whether the cookie is checked for attack signatures.", true),
+					},
+				},
+			},
+			"headers": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "HTTP headers of the policy.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the header.",
+						},
+						"type":             wafPolicyEntityType("header"),
+						"mandatory":        wafPolicyBool("Whether the header must be present in requests.", false),
+						"check_signatures": wafPolicyBool("Whether the header is checked for attack signatures.", true),
+					},
+				},
+			},
+			"server_technologies": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Server technologies of the application, such as `Apache Tomcat`, enabling the signatures of these technologies.",
+			},
+			"declaration_json": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Declaration of the policy in JSON, an object with the `policy` of the declaration as exported from Central Manager. " +
+					"Use it for the settings of the policy which are not attributes of this resource, the attributes of this resource take precedence over the same settings of the declaration. " +
+					"Differences in formatting and key order of the JSON are not changes of the policy.",
+				PlanModifiers: []planmodifier.String{
+					SuppressDiffAs3(),
+				},
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Unique Identifier for the resource",
@@ -184,6 +385,22 @@ func (r *NextCMWAFPolicyResource) Schema(ctx context.Context, req resource.Schem
 	}
 }
 
+func (r *NextCMWAFPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NextCMWAFPolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.DeclarationJSON.IsNull() || data.DeclarationJSON.IsUnknown() {
+		return
+	}
+	var declaration map[string]interface{}
+	if err := json.Unmarshal([]byte(data.DeclarationJSON.ValueString()), &declaration); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("declaration_json"), "Invalid Declaration", fmt.Sprintf("The declaration is not a valid JSON object: %s", err))
+		return
+	}
+	if _, ok := declaration["policy"].(map[string]interface{}); !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("declaration_json"), "Invalid Declaration", "The declaration must have a `policy` object")
+	}
+}
+
 func (r *NextCMWAFPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
 }
@@ -199,7 +416,12 @@ func (r *NextCMWAFPolicyResource) Create(ctx context.Context, req resource.Creat
 	reqDraft := getCMWAFPolicyRequestDraft(ctx, resCfg)
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] WAF Policy config :%+v\n", reqDraft))
 
-	id, err := r.client.PostWAFPolicy("POST", reqDraft)
+	body, err := wafPolicyRequestBody(reqDraft, wafPolicyDeclarationEntities(ctx, resCfg), resCfg.DeclarationJSON.ValueString())
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("WAF Policy Error", fmt.Sprintf("Failed to Create WAF Policy, got error: %s", err))
+		return
+	}
+	id, err := postWAFPolicyBody(r.client, "POST", "", body)
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("WAF Policy Error", fmt.Sprintf("Failed to Create WAF Policy, got error: %s", err))
 		return
@@ -279,7 +501,12 @@ func (r *NextCMWAFPolicyResource) Update(ctx context.Context, req resource.Updat
 	tflog.Info(ctx, "[UPDATE] Updating WAF Policy")
 	tflog.Info(ctx, fmt.Sprintf("[UPDATE] :%+v\n", reqDraft))
 
-	id, err := r.client.PostWAFPolicy("PUT", reqDraft)
+	body, err := wafPolicyRequestBody(reqDraft, wafPolicyDeclarationEntities(ctx, resCfg), resCfg.DeclarationJSON.ValueString())
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Update WAF Policy, got error: %s", err))
+		return
+	}
+	id, err := postWAFPolicyBody(r.client, "PUT", reqDraft.Id, body)
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Update WAF Policy, got error: %s", err))
 		return
@@ -311,8 +538,32 @@ func (r *NextCMWAFPolicyResource) Delete(ctx context.Context, req resource.Delet
 	stateCfg.Id = types.StringValue("")
 }
 
-func (r *NextCMWAFPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) { // coverage-ignore
-	// resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+// ImportState accepts either the ID or the name of the WAF Policy
+func (r *NextCMWAFPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveWafPolicyID(r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Import WAF Policy %s, got error: %s", req.ID, err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+var wafPolicyIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// resolveWafPolicyID returns idOrName unchanged when it is a policy ID, otherwise the ID of the policy with that name
+func resolveWafPolicyID(client *bigipnextsdk.BigipNextCM, idOrName string) (string, error) {
+	if wafPolicyIDRegexp.MatchString(idOrName) {
+		return idOrName, nil
+	}
+	policy, err := getWAFPolicyByName(client, idOrName)
+	if err != nil {
+		return "", err
+	}
+	id, ok := policy["id"].(string)
+	if !ok { // coverage-ignore
+		return "", fmt.Errorf("WAF policy %q has no id", idOrName)
+	}
+	return id, nil
 }
 
 func getCMWAFPolicyRequestDraft(ctx context.Context, data *NextCMWAFPolicyResourceModel) *bigipnextsdk.CMWAFPolicyRequestDraft {
@@ -415,6 +666,7 @@ func (r *NextCMWAFPolicyResource) WafPolicyModeltoState(ctx context.Context, res
 		_, ok = respData.(map[string]interface{})["declaration"].(map[string]interface{})["policy"]
 		// fetching the settings of bot-defense, ip-intelligence, dos-protection, blocking-settings from Policy
 		if ok {
+			wafPolicyDeclarationToState(ctx, respData.(map[string]interface{})["declaration"].(map[string]interface{})["policy"].(map[string]interface{}), data)
 			_, ok = respData.(map[string]interface{})["declaration"].(map[string]interface{})["policy"].(map[string]interface{})["bot-defense"]
 			if ok {
 				var botdefenseModel BotDefenseModel
@@ -1,11 +1,13 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccNextCMWAFPolicyCreateTC1Resource(t *testing.T) {
@@ -166,6 +168,101 @@ func TestUnitCMWAFPolicyCreateUnitTC2Resource(t *testing.T) {
 	})
 }
 
+func TestUnitCMWAFPolicyDeclarationUnitTC3Resource(t *testing.T) {
+	testAccPreUnitCheck(t)
+	var policyBodies []map[string]interface{}
+	mux.HandleFunc("/api/login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"access_token": "Zj7421sAIkQF4YeRfKdMvQv","refresh_token": "Zj7421sAIkQF4YeRfKdMvQv","user_id": "6dd0d482-267e-4916-b524-ee8e5dd1c78"}`)
+	})
+	policy := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" || r.Method == "PUT" {
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			body["id"] = "5cbe0f62-d3ad-4ba1-a4a3-35f59b2cbd62"
+			policyBodies = append(policyBodies, body)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"id":"5cbe0f62-d3ad-4ba1-a4a3-35f59b2cbd62"}`)
+			return
+		}
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		// the policy is read back as it was posted, with the url defined by the template
+		body := policyBodies[len(policyBodies)-1]
+		policy := body["declaration"].(map[string]interface{})["policy"].(map[string]interface{})
+		policy["urls"] = append(policy["urls"].([]interface{}), map[string]interface{}{"name": "*", "method": "*", "protocol": "http", "type": "wildcard", "isAllowed": true})
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(body)
+	}
+	mux.HandleFunc("/api/v1/spaces/default/security/waf-policies", policy)
+	mux.HandleFunc("/api/v1/spaces/default/security/waf-policies/5cbe0f62-d3ad-4ba1-a4a3-35f59b2cbd62", policy)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitNextCMWAFPolicyDeclarationConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "id", "5cbe0f62-d3ad-4ba1-a4a3-35f59b2cbd62"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "urls.#", "1"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "urls.0.name", "/login"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "urls.0.method", "POST"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "urls.0.protocol", "https"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "urls.0.is_allowed", "true"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "parameters.0.sensitive_parameter", "true"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "parameters.0.data_type", "alpha-numeric"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "filetypes.0.allowed", "false"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "signature_sets.0.block", "true"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "signatures.0.signature_id", "200001834"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "signatures.0.enabled", "false"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "whitelist_ips.0.block_requests", "never"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "cookies.0.enforcement_type", "enforce"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "headers.0.mandatory", "true"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "server_technologies.0", "Apache Tomcat"),
+					func(s *terraform.State) error {
+						policy := policyBodies[0]["declaration"].(map[string]interface{})["policy"].(map[string]interface{})
+						if policy["general"].(map[string]interface{})["trustXff"] != true {
+							return fmt.Errorf("expected the settings of declaration_json to be posted, got %v", policy["general"])
+						}
+						if policy["bot-defense"].(map[string]interface{})["settings"].(map[string]interface{})["isEnabled"] != false {
+							return fmt.Errorf("expected bot_defense to take precedence over declaration_json")
+						}
+						if violations := policy["blocking-settings"].(map[string]interface{})["violations"].([]interface{}); len(violations) != 2 {
+							return fmt.Errorf("expected the violations of declaration_json and blocking_settings to be posted, got %v", violations)
+						}
+						if policy["server-technologies"].([]interface{})[0].(map[string]interface{})["serverTechnologyName"] != "Apache Tomcat" {
+							return fmt.Errorf("expected the server technologies to be posted, got %v", policy["server-technologies"])
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// only the formatting of declaration_json changes
+				Config:   testUnitNextCMWAFPolicyDeclarationReformattedConfig,
+				PlanOnly: true,
+			},
+			{
+				Config: testUnitNextCMWAFPolicyDeclarationUpdateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "urls.#", "2"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "urls.1.name", "/admin"),
+					resource.TestCheckResourceAttr("bigipnext_cm_waf_policy.declaration", "urls.1.is_allowed", "false"),
+					func(s *terraform.State) error {
+						if len(policyBodies) != 2 {
+							return fmt.Errorf("expected the policy to be updated once, got %d requests", len(policyBodies))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 const testAccNextCMWAFPolicyResourceConfig = `
 resource "bigipnext_cm_waf_policy" "sample" {
 	name                 = "new_waf_policy"
@@ -209,3 +306,204 @@ resource "bigipnext_cm_waf_policy" "sample" {
 		enabled = false
 	  }
   }`
+
+const testUnitNextCMWAFPolicyDeclarationConfig = `
+resource "bigipnext_cm_waf_policy" "declaration" {
+  name                 = "declaration_policy"
+  enforcement_mode     = "blocking"
+  application_language = "utf-8"
+  template_name        = "Rating-Based-Template"
+  bot_defense = {
+    enabled = false
+  }
+  declaration_json = jsonencode({ policy = { general = { trustXff = true }, bot-defense = { settings = { isEnabled = true } }, blocking-settings = { violations = [{ name = "VIOL_EVASION", alarm = true, block = true }] } } })
+  urls = [
+    {
+      name     = "/login"
+      method   = "POST"
+      protocol = "https"
+    },
+  ]
+  parameters = [
+    {
+      name                = "password"
+      sensitive_parameter = true
+    }
+  ]
+  filetypes = [
+    {
+      name    = "exe"
+      allowed = false
+    }
+  ]
+  signature_sets = [
+    {
+      name = "High Accuracy Signatures"
+    }
+  ]
+  signatures = [
+    {
+      signature_id = 200001834
+      enabled      = false
+    }
+  ]
+  whitelist_ips = [
+    {
+      ip_address     = "10.10.10.0"
+      ip_mask        = "255.255.255.0"
+      block_requests = "never"
+    }
+  ]
+  cookies = [
+    {
+      name             = "session"
+      enforcement_type = "enforce"
+    }
+  ]
+  headers = [
+    {
+      name      = "x-api-key"
+      mandatory = true
+    }
+  ]
+  server_technologies = ["Apache Tomcat"]
+}
+`
+
+const testUnitNextCMWAFPolicyDeclarationReformattedConfig = `
+resource "bigipnext_cm_waf_policy" "declaration" {
+  name                 = "declaration_policy"
+  enforcement_mode     = "blocking"
+  application_language = "utf-8"
+  template_name        = "Rating-Based-Template"
+  bot_defense = {
+    enabled = false
+  }
+  declaration_json = <<EOT
+{
+  "policy": {
+    "blocking-settings": {"violations": [{"block": true, "alarm": true, "name": "VIOL_EVASION"}]},
+    "bot-defense": {"settings": {"isEnabled": true}},
+    "general": {"trustXff": true}
+  }
+}
+EOT
+  urls = [
+    {
+      name     = "/login"
+      method   = "POST"
+      protocol = "https"
+    },
+  ]
+  parameters = [
+    {
+      name                = "password"
+      sensitive_parameter = true
+    }
+  ]
+  filetypes = [
+    {
+      name    = "exe"
+      allowed = false
+    }
+  ]
+  signature_sets = [
+    {
+      name = "High Accuracy Signatures"
+    }
+  ]
+  signatures = [
+    {
+      signature_id = 200001834
+      enabled      = false
+    }
+  ]
+  whitelist_ips = [
+    {
+      ip_address     = "10.10.10.0"
+      ip_mask        = "255.255.255.0"
+      block_requests = "never"
+    }
+  ]
+  cookies = [
+    {
+      name             = "session"
+      enforcement_type = "enforce"
+    }
+  ]
+  headers = [
+    {
+      name      = "x-api-key"
+      mandatory = true
+    }
+  ]
+  server_technologies = ["Apache Tomcat"]
+}
+`
+
+const testUnitNextCMWAFPolicyDeclarationUpdateConfig = `
+resource "bigipnext_cm_waf_policy" "declaration" {
+  name                 = "declaration_policy"
+  enforcement_mode     = "blocking"
+  application_language = "utf-8"
+  template_name        = "Rating-Based-Template"
+  bot_defense = {
+    enabled = false
+  }
+  declaration_json = jsonencode({ policy = { general = { trustXff = true }, bot-defense = { settings = { isEnabled = true } }, blocking-settings = { violations = [{ name = "VIOL_EVASION", alarm = true, block = true }] } } })
+  urls = [
+    {
+      name     = "/login"
+      method   = "POST"
+      protocol = "https"
+    },
+    {
+      name       = "/admin"
+      is_allowed = false
+    },
+  ]
+  parameters = [
+    {
+      name                = "password"
+      sensitive_parameter = true
+    }
+  ]
+  filetypes = [
+    {
+      name    = "exe"
+      allowed = false
+    }
+  ]
+  signature_sets = [
+    {
+      name = "High Accuracy Signatures"
+    }
+  ]
+  signatures = [
+    {
+      signature_id = 200001834
+      enabled      = false
+    }
+  ]
+  whitelist_ips = [
+    {
+      ip_address     = "10.10.10.0"
+      ip_mask        = "255.255.255.0"
+      block_requests = "never"
+    }
+  ]
+  cookies = [
+    {
+      name             = "session"
+      enforcement_type = "enforce"
+    }
+  ]
+  headers = [
+    {
+      name      = "x-api-key"
+      mandatory = true
+    }
+  ]
+  server_technologies = ["Apache Tomcat"]
+}
+`
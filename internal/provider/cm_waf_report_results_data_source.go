@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMWAFReportResultsDataSource{}
+)
+
+func NewCMWAFReportResultsDataSource() datasource.DataSource {
+	return &CMWAFReportResultsDataSource{}
+}
+
+// CMWAFReportResultsDataSource defines the data source implementation.
+type CMWAFReportResultsDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMWAFReportResultsDataSourceModel describes the data source data model.
+type CMWAFReportResultsDataSourceModel struct {
+	ReportId      types.String                     `tfsdk:"report_id"`
+	ReportName    types.String                     `tfsdk:"report_name"`
+	TopN          types.Int64                      `tfsdk:"top_n"`
+	Categories    []types.String                   `tfsdk:"categories"`
+	GeneratedTime types.String                     `tfsdk:"generated_time"`
+	Results       []CMWAFReportCategoryResultModel `tfsdk:"results"`
+	ID            types.String                     `tfsdk:"id"`
+}
+
+type CMWAFReportCategoryResultModel struct {
+	Category types.String            `tfsdk:"category"`
+	Entries  []CMWAFReportEntryModel `tfsdk:"entries"`
+}
+
+type CMWAFReportEntryModel struct {
+	Name  types.String `tfsdk:"name"`
+	Count types.Int64  `tfsdk:"count"`
+}
+
+type wafReportResults struct {
+	GeneratedTime string `json:"generated_time"`
+	Categories    []struct {
+		Name    string           `json:"name"`
+		Entries []wafReportEntry `json:"entries"`
+	} `json:"categories"`
+}
+
+type wafReportEntry struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+func (d *CMWAFReportResultsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_waf_report_results"
+}
+
+func (d *CMWAFReportResultsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Execute a WAF Security Report defined on BIG-IP Next Central Manager, such as with `bigipnext_cm_waf_report`, " +
+			"and get the top entries of each of its categories.",
+		Attributes: map[string]schema.Attribute{
+			"report_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the security report. Exactly one of `report_id` or `report_name` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("report_id"), path.MatchRoot("report_name")),
+				},
+			},
+			"report_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the security report.",
+			},
+			"top_n": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Maximum number of entries returned for each category. By default all entries returned by the report, up to its `top_level`.",
+			},
+			"categories": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only return the results of these categories, such as `Source IPs`, `URLs`, `Signatures` or `Attack Types`.",
+			},
+			"generated_time": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the results were generated. In ISO 8601 format.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Top entries of each category of the report.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"category": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the category.",
+						},
+						"entries": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Entries of the category, with the highest count first.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "Name of the entry, such as a source IP, URL or signature name.",
+									},
+									"count": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "Number of requests of the entry in the report time frame.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this data source.",
+			},
+		},
+	}
+}
+
+func (d *CMWAFReportResultsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMWAFReportResultsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMWAFReportResultsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	reportId := data.ReportId.ValueString()
+	if data.ReportId.IsNull() {
+		var err error
+		reportId, err = getWAFReportByName(d.client, data.ReportName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read WAF report %s, got error: %s", data.ReportName.ValueString(), err))
+			return
+		}
+	}
+	respData, err := getWAFReportResults(d.client, reportId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read WAF report results, got error: %s", err))
+		return
+	}
+	var results wafReportResults
+	if err := json.Unmarshal(respData, &results); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse WAF report results, got error: %s", err))
+		return
+	}
+	var categories []string
+	for _, category := range data.Categories {
+		categories = append(categories, category.ValueString())
+	}
+
+	data.Results = []CMWAFReportCategoryResultModel{}
+	for _, category := range results.Categories {
+		if len(categories) > 0 && !slices.Contains(categories, category.Name) {
+			continue
+		}
+		categoryModel := CMWAFReportCategoryResultModel{
+			Category: types.StringValue(category.Name),
+			Entries:  []CMWAFReportEntryModel{},
+		}
+		entries := category.Entries
+		slices.SortStableFunc(entries, func(a, b wafReportEntry) int {
+			return cmp.Compare(b.Count, a.Count)
+		})
+		for i, entry := range entries {
+			if !data.TopN.IsNull() && int64(i) >= data.TopN.ValueInt64() {
+				break
+			}
+			categoryModel.Entries = append(categoryModel.Entries, CMWAFReportEntryModel{
+				Name:  types.StringValue(entry.Name),
+				Count: types.Int64Value(entry.Count),
+			})
+		}
+		data.Results = append(data.Results, categoryModel)
+	}
+	data.GeneratedTime = types.StringValue(results.GeneratedTime)
+	data.ID = types.StringValue(reportId)
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
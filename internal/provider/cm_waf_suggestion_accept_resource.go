@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ resource.Resource = &NextCMWAFSuggestionAcceptResource{}
+)
+
+func NewNextCMWAFSuggestionAcceptResource() resource.Resource {
+	return &NextCMWAFSuggestionAcceptResource{}
+}
+
+type NextCMWAFSuggestionAcceptResource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+type NextCMWAFSuggestionAcceptResourceModel struct {
+	Policy        types.String `tfsdk:"policy"`
+	SuggestionIds types.List   `tfsdk:"suggestion_ids"`
+	PolicyId      types.String `tfsdk:"policy_id"`
+	AcceptedIds   types.List   `tfsdk:"accepted_ids"`
+	Id            types.String `tfsdk:"id"`
+}
+
+func (r *NextCMWAFSuggestionAcceptResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_waf_suggestion_accept"
+}
+
+func (r *NextCMWAFSuggestionAcceptResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resource used to accept a reviewed set of policy builder learning suggestions of a WAF Policy on BIG-IP Next Central Manager, " +
+			"such as the IDs returned by `bigipnext_cm_waf_suggestions`.\n\n" +
+			"Suggestions added to `suggestion_ids` are accepted on the next apply. Accepted suggestions become part of the policy, " +
+			"removing them from `suggestion_ids` or doing `terraform destroy` does not revert them.",
+		Attributes: map[string]schema.Attribute{
+			"policy": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name or ID of the WAF Policy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"suggestion_ids": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the reviewed suggestions to accept.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the WAF Policy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"accepted_ids": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the suggestions accepted by this resource. Suggestions of `suggestion_ids` already accepted on CM are not accepted again.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique Identifier for the resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *NextCMWAFSuggestionAcceptResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (r *NextCMWAFSuggestionAcceptResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var resCfg *NextCMWAFSuggestionAcceptResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	policyId, err := resolveWafPolicyID(r.client, resCfg.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read WAF Policy %s, got error: %s", resCfg.Policy.ValueString(), err))
+		return
+	}
+	resCfg.PolicyId = types.StringValue(policyId)
+	resCfg.Id = types.StringValue(policyId)
+	resp.Diagnostics.Append(r.acceptSuggestions(ctx, resCfg, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
+}
+
+func (r *NextCMWAFSuggestionAcceptResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var stateCfg *NextCMWAFSuggestionAcceptResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	id := stateCfg.PolicyId.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("[READ] Reading WAF Policy : %s", id))
+	_, err := r.client.GetWAFPolicyDetails(id)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Warn(ctx, fmt.Sprintf("[READ] WAF Policy %s not found, removing from state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read WAF Policy, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+}
+
+func (r *NextCMWAFSuggestionAcceptResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var resCfg, stateCfg *NextCMWAFSuggestionAcceptResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	var previous []string
+	resp.Diagnostics.Append(stateCfg.SuggestionIds.ElementsAs(ctx, &previous, false)...)
+	resCfg.PolicyId = stateCfg.PolicyId
+	resCfg.Id = stateCfg.Id
+	resCfg.AcceptedIds = stateCfg.AcceptedIds
+	resp.Diagnostics.Append(r.acceptSuggestions(ctx, resCfg, previous)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
+}
+
+func (r *NextCMWAFSuggestionAcceptResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var stateCfg *NextCMWAFSuggestionAcceptResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	// accepted suggestions are part of the policy now, there is nothing to undo on CM
+	tflog.Info(ctx, fmt.Sprintf("[DELETE] Removing accepted suggestions of WAF Policy %s from state", stateCfg.PolicyId.ValueString()))
+}
+
+// acceptSuggestions accepts the suggestions of data which are not in previous and not accepted yet, and records
+// them in accepted_ids
+func (r *NextCMWAFSuggestionAcceptResource) acceptSuggestions(ctx context.Context, data *NextCMWAFSuggestionAcceptResourceModel, previous []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var suggestionIds []string
+	diags.Append(data.SuggestionIds.ElementsAs(ctx, &suggestionIds, false)...)
+	if diags.HasError() { // coverage-ignore
+		return diags
+	}
+	suggestions, err := getWAFPolicySuggestions(r.client, data.PolicyId.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Failed to Read WAF Policy suggestions, got error: %s", err))
+		return diags
+	}
+	status := make(map[string]string)
+	for _, suggestion := range suggestions {
+		status[suggestion.Id] = suggestion.Status
+	}
+
+	var accepted []string
+	if !data.AcceptedIds.IsNull() && !data.AcceptedIds.IsUnknown() {
+		diags.Append(data.AcceptedIds.ElementsAs(ctx, &accepted, false)...)
+	}
+	var toAccept, missing []string
+	for _, id := range suggestionIds {
+		if slices.Contains(previous, id) {
+			continue
+		}
+		suggestionStatus, ok := status[id]
+		switch {
+		case !ok:
+			missing = append(missing, id)
+		case suggestionStatus != "accepted":
+			toAccept = append(toAccept, id)
+		}
+	}
+	if len(missing) > 0 {
+		diags.AddError("Suggestion Not Found", fmt.Sprintf("WAF Policy %s has no suggestions with IDs: %s", data.Policy.ValueString(), strings.Join(missing, ", ")))
+		return diags
+	}
+	if len(toAccept) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("[acceptSuggestions] Accepting suggestions %v of WAF Policy %s", toAccept, data.PolicyId.ValueString()))
+		if _, err := acceptWAFPolicySuggestions(r.client, data.PolicyId.ValueString(), toAccept); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Failed to Accept WAF Policy suggestions, got error: %s", err))
+			return diags
+		}
+	}
+	accepted = append([]string{}, accepted...)
+	accepted = append(accepted, toAccept...)
+	acceptedIds, listDiags := types.ListValueFrom(ctx, types.StringType, accepted)
+	diags.Append(listDiags...)
+	data.AcceptedIds = acceptedIds
+	return diags
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+)
+
+var (
+	_ datasource.DataSource = &CMWAFSuggestionsDataSource{}
+)
+
+func NewCMWAFSuggestionsDataSource() datasource.DataSource {
+	return &CMWAFSuggestionsDataSource{}
+}
+
+// CMWAFSuggestionsDataSource defines the data source implementation.
+type CMWAFSuggestionsDataSource struct {
+	client *bigipnextsdk.BigipNextCM
+}
+
+// CMWAFSuggestionsDataSourceModel describes the data source data model.
+type CMWAFSuggestionsDataSourceModel struct {
+	Policy           types.String                 `tfsdk:"policy"`
+	MinLearningScore types.Int64                  `tfsdk:"min_learning_score"`
+	SuggestionType   types.String                 `tfsdk:"suggestion_type"`
+	PolicyId         types.String                 `tfsdk:"policy_id"`
+	Suggestions      []CMWAFSuggestionSourceModel `tfsdk:"suggestions"`
+	SuggestionIds    []types.String               `tfsdk:"suggestion_ids"`
+	ID               types.String                 `tfsdk:"id"`
+}
+
+type CMWAFSuggestionSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Type          types.String `tfsdk:"type"`
+	Action        types.String `tfsdk:"action"`
+	Description   types.String `tfsdk:"description"`
+	LearningScore types.Int64  `tfsdk:"learning_score"`
+	Status        types.String `tfsdk:"status"`
+}
+
+func (d *CMWAFSuggestionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cm_waf_suggestions"
+}
+
+func (d *CMWAFSuggestionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the policy builder learning suggestions of a WAF Policy on BIG-IP Next Central Manager. " +
+			"Reviewed suggestions can be accepted with `bigipnext_cm_waf_suggestion_accept`.",
+		Attributes: map[string]schema.Attribute{
+			"policy": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name or ID of the WAF Policy.",
+			},
+			"min_learning_score": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return suggestions with a learning score of at least this value, from `0` to `100`.",
+			},
+			"suggestion_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return suggestions of this type, such as `url`, `parameter`, `signature` or `violation`.",
+			},
+			"policy_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "ID of the WAF Policy.",
+			},
+			"suggestions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "List of learning suggestions matching the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "ID of the suggestion.",
+						},
+						"type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Type of policy entity the suggestion applies to.",
+						},
+						"action": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Action the suggestion takes on the policy, such as `add-url` or `disable-signature`.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Description of the suggestion.",
+						},
+						"learning_score": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "Learning score of the suggestion, from `0` to `100`.",
+						},
+						"status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Status of the suggestion, such as `pending` or `accepted`.",
+						},
+					},
+				},
+			},
+			"suggestion_ids": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the suggestions matching the filters.",
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Unique identifier of this data source.",
+			},
+		},
+	}
+}
+
+func (d *CMWAFSuggestionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	d.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
+}
+
+func (d *CMWAFSuggestionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CMWAFSuggestionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+
+	policyId, err := resolveWafPolicyID(d.client, data.Policy.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read WAF policy %s, got error: %s", data.Policy.ValueString(), err))
+		return
+	}
+	suggestions, err := getWAFPolicySuggestions(d.client, policyId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Read WAF Policy suggestions, got error: %s", err))
+		return
+	}
+	data.Suggestions = []CMWAFSuggestionSourceModel{}
+	data.SuggestionIds = []types.String{}
+	for _, suggestion := range suggestions {
+		if !data.MinLearningScore.IsNull() && suggestion.LearningScore < float64(data.MinLearningScore.ValueInt64()) {
+			continue
+		}
+		if !data.SuggestionType.IsNull() && suggestion.Type != data.SuggestionType.ValueString() {
+			continue
+		}
+		data.Suggestions = append(data.Suggestions, CMWAFSuggestionSourceModel{
+			Id:            types.StringValue(suggestion.Id),
+			Type:          types.StringValue(suggestion.Type),
+			Action:        types.StringValue(suggestion.Action),
+			Description:   types.StringValue(suggestion.Description),
+			LearningScore: types.Int64Value(int64(math.Round(suggestion.LearningScore))),
+			Status:        types.StringValue(suggestion.Status),
+		})
+		data.SuggestionIds = append(data.SuggestionIds, types.StringValue(suggestion.Id))
+	}
+	data.PolicyId = types.StringValue(policyId)
+	data.ID = types.StringValue(policyId)
+
+	tflog.Trace(ctx, "read a data source")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -30,12 +31,14 @@ type NextCMDeviceProviderResource struct {
 }
 
 type NextCMDeviceProviderResourceModel struct {
-	Type     types.String `tfsdk:"type"`
-	Name     types.String `tfsdk:"name"`
-	Address  types.String `tfsdk:"address"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	Id       types.String `tfsdk:"id"`
+	Type                 types.String `tfsdk:"type"`
+	Name                 types.String `tfsdk:"name"`
+	Address              types.String `tfsdk:"address"`
+	Username             types.String `tfsdk:"username"`
+	Password             types.String `tfsdk:"password"`
+	ValidateConnectivity types.Bool   `tfsdk:"validate_connectivity"`
+	ConnectionStatus     types.String `tfsdk:"connection_status"`
+	Id                   types.String `tfsdk:"id"`
 }
 
 func (r *NextCMDeviceProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -70,6 +73,16 @@ func (r *NextCMDeviceProviderResource) Schema(ctx context.Context, req resource.
 				Sensitive:           true,
 				MarkdownDescription: "The password that the BIG-IP Next Central Manager uses when connecting with the specified provider",
 			},
+			"validate_connectivity": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "When `true`, BIG-IP Next Central Manager tests the connection to the provider with the given address and credentials before the provider is created or updated, and the apply fails if the provider cannot be reached. The connection is tested again on every refresh to update `connection_status`. Default is `false`.",
+				Default:             booldefault.StaticBool(false),
+			},
+			"connection_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status of the connection from BIG-IP Next Central Manager to the provider, `CONNECTED` or `DISCONNECTED` when `validate_connectivity` is `true`, `NOT_VALIDATED` otherwise.",
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Unique Identifier for the resource",
@@ -95,6 +108,15 @@ func (r *NextCMDeviceProviderResource) Create(ctx context.Context, req resource.
 
 	providerConfig := getDeviceProvider(ctx, resCfg)
 
+	if resCfg.ValidateConnectivity.ValueBool() {
+		tflog.Info(ctx, fmt.Sprintf("[CREATE] Testing connection to provider: %s", resCfg.Address.ValueString()))
+		if err := testCMProviderConnection(r.client, getDeviceProvider(ctx, resCfg)); err != nil {
+			resp.Diagnostics.AddError("Provider Connectivity Error", fmt.Sprintf("Central Manager could not connect to the %s provider %s, the provider was not created: %s", resCfg.Type.ValueString(), resCfg.Address.ValueString(), err))
+			return
+		}
+	}
+	resCfg.ConnectionStatus = types.StringValue(deviceProviderConnectionStatus(resCfg.ValidateConnectivity.ValueBool(), nil))
+
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] Device Provider config:%+v\n", providerConfig))
 	respData, err := r.client.PostDeviceProvider(providerConfig)
 
@@ -127,6 +149,26 @@ func (r *NextCMDeviceProviderResource) Read(ctx context.Context, req resource.Re
 	}
 	tflog.Info(ctx, fmt.Sprintf("Device Provider : %+v", deviceProvider))
 	r.DeviceProviderResourceModeltoState(ctx, deviceProvider, stateCfg)
+	if stateCfg.ValidateConnectivity.IsNull() {
+		stateCfg.ValidateConnectivity = types.BoolValue(false)
+	}
+	// the password is not read back from CM, an imported provider has none to test the connection with
+	if stateCfg.ValidateConnectivity.ValueBool() && stateCfg.Password.IsNull() {
+		tflog.Info(ctx, fmt.Sprintf("Skipping connection test of Device Provider %s without password", stateCfg.Name.ValueString()))
+		if stateCfg.ConnectionStatus.IsNull() {
+			stateCfg.ConnectionStatus = types.StringValue(deviceProviderConnectionStatus(false, nil))
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
+		return
+	}
+	var connErr error
+	if stateCfg.ValidateConnectivity.ValueBool() {
+		connErr = testCMProviderConnection(r.client, getDeviceProvider(ctx, stateCfg))
+		if connErr != nil {
+			resp.Diagnostics.AddWarning("Provider Connectivity Error", fmt.Sprintf("Central Manager could not connect to the %s provider %s: %s", stateCfg.Type.ValueString(), stateCfg.Address.ValueString(), connErr))
+		}
+	}
+	stateCfg.ConnectionStatus = types.StringValue(deviceProviderConnectionStatus(stateCfg.ValidateConnectivity.ValueBool(), connErr))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
 }
 
@@ -148,6 +190,14 @@ func (r *NextCMDeviceProviderResource) Update(ctx context.Context, req resource.
 	tflog.Info(ctx, fmt.Sprintf("[UPDATE] Updating Device Provider: %s", resCfg.Name.ValueString()))
 
 	providerConfig := getDeviceProvider(ctx, resCfg)
+	if resCfg.ValidateConnectivity.ValueBool() {
+		tflog.Info(ctx, fmt.Sprintf("[UPDATE] Testing connection to provider: %s", resCfg.Address.ValueString()))
+		if err := testCMProviderConnection(r.client, getDeviceProvider(ctx, resCfg)); err != nil {
+			resp.Diagnostics.AddError("Provider Connectivity Error", fmt.Sprintf("Central Manager could not connect to the %s provider %s, the provider was not updated: %s", resCfg.Type.ValueString(), resCfg.Address.ValueString(), err))
+			return
+		}
+	}
+	resCfg.ConnectionStatus = types.StringValue(deviceProviderConnectionStatus(resCfg.ValidateConnectivity.ValueBool(), nil))
 	tflog.Info(ctx, fmt.Sprintf("[UPDATE] Device Provider config:%+v\n", providerConfig))
 
 	respData, err := r.client.UpdateDeviceProvider(resCfg.Id.ValueString(), providerConfig)
@@ -193,3 +243,14 @@ func getDeviceProvider(ctx context.Context, data *NextCMDeviceProviderResourceMo
 	tflog.Info(ctx, fmt.Sprintf("deviceProvider:%+v\n", deviceProvider))
 	return &deviceProvider
 }
+
+// deviceProviderConnectionStatus returns the connection_status of a provider, from the error of its connection test
+func deviceProviderConnectionStatus(validated bool, connErr error) string {
+	if !validated {
+		return "NOT_VALIDATED"
+	}
+	if connErr != nil {
+		return "DISCONNECTED"
+	}
+	return "CONNECTED"
+}
@@ -3,12 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -32,12 +36,16 @@ type NextGlobalResiliencyResource struct {
 }
 
 type NextGlobalResiliencyResourceModel struct {
-	Name            types.String `tfsdk:"name"`
-	DNSListenerName types.String `tfsdk:"dns_listener_name"`
-	Protocols       types.List   `tfsdk:"protocols"`
-	Instances       []Instance   `tfsdk:"instances"`
-	DNSListenerPort types.Int64  `tfsdk:"dns_listener_port"`
-	Id              types.String `tfsdk:"id"`
+	Name            types.String             `tfsdk:"name"`
+	DNSListenerName types.String             `tfsdk:"dns_listener_name"`
+	Protocols       types.List               `tfsdk:"protocols"`
+	Instances       []Instance               `tfsdk:"instances"`
+	DNSListenerPort types.Int64              `tfsdk:"dns_listener_port"`
+	Timeout         types.Int64              `tfsdk:"timeout"`
+	Status          types.String             `tfsdk:"status"`
+	Health          types.String             `tfsdk:"health"`
+	InstanceStatus  []GlobalResiliencyStatus `tfsdk:"instance_status"`
+	Id              types.String             `tfsdk:"id"`
 }
 
 type Instance struct {
@@ -47,13 +55,21 @@ type Instance struct {
 	GroupSyncAddress   types.String `tfsdk:"group_sync_address"`
 }
 
+type GlobalResiliencyStatus struct {
+	Hostname          types.String `tfsdk:"hostname"`
+	Address           types.String `tfsdk:"address"`
+	SyncStatus        types.String `tfsdk:"sync_status"`
+	DNSListenerHealth types.String `tfsdk:"dns_listener_health"`
+}
+
 func (r *NextGlobalResiliencyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_cm_global_resiliency"
 }
 
 func (r *NextGlobalResiliencyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used to manage(CRUD) Global Resiliency resources onto BIG-IP Next CM.",
+		MarkdownDescription: "Resource used to manage(CRUD) Global Resiliency resources onto BIG-IP Next CM.\n\n" +
+			"Changing `instances` updates the group in place with the new list of instances, without recreating it.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -138,6 +154,47 @@ func (r *NextGlobalResiliencyResource) Schema(ctx context.Context, req resource.
 					},
 				},
 			},
+			"timeout": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The number of seconds to wait for the group to be deployed or deleted.",
+				Default:             int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Deployment status of the group, such as `DEPLOYED`, `DEPLOYING` or `FAILED`.",
+			},
+			"health": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Health of the DNS listeners of the group, such as `GOOD`.",
+			},
+			"instance_status": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Status of each instance of the group, as reported by CM.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hostname": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Hostname of the instance.",
+						},
+						"address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Address of the instance.",
+						},
+						"sync_status": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Synchronization status of the instance with the group, empty when CM does not report it.",
+						},
+						"dns_listener_health": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Health of the DNS listener of the instance, such as `GOOD`.",
+						},
+					},
+				},
+			},
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Unique Identifier for the resource",
@@ -167,15 +224,15 @@ func (r *NextGlobalResiliencyResource) Create(ctx context.Context, req resource.
 	tflog.Info(ctx, "[CREATE]  Global Resiliency Group")
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] :%+v\n", reqDraft))
 
-	id, err := r.client.PostGlobalResiliencyGroup("POST", reqDraft)
-	if err != nil { // coverage-ignore
+	grData, err := postGlobalResiliencyGroup(r.client, "POST", reqDraft, resCfg.Timeout.ValueInt64())
+	if err != nil {
 		resp.Diagnostics.AddError("Global Resiliency Error", fmt.Sprintf("Failed to Create Global Resiliency Group, got error: %s", err))
 		return
 	}
 
 	tflog.Info(ctx, "[CREATE] Global Resiliency Group created successfully")
 
-	resCfg.Id = types.StringValue(id)
+	r.GlobalResilienceModeltoState(ctx, grData, resCfg)
 	resp.Diagnostics.Append(resp.State.Set(ctx, resCfg)...)
 
 }
@@ -189,38 +246,89 @@ func (r *NextGlobalResiliencyResource) Read(ctx context.Context, req resource.Re
 	id := stateCfg.Id.ValueString()
 	tflog.Info(ctx, fmt.Sprintf("Reading Global Resiliency Group : %s", id))
 	grData, err := r.client.GetGlobalResiliencyGroupDetails(id)
+	if err != nil && strings.Contains(err.Error(), `"code":404`) {
+		tflog.Warn(ctx, fmt.Sprintf("Global Resiliency Group %s not found, removing from state", id))
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Error", fmt.Sprintf("Failed to Read  Global Resiliency Group, got error: %s", err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("Global Resiliency Group : %+v", grData))
-	r.GlobalResilienceModeltoState(ctx, grData, stateCfg)
+	r.GlobalResilienceModeltoState(ctx, grData.(map[string]interface{}), stateCfg)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &stateCfg)...)
 }
 
-func (r *NextGlobalResiliencyResource) GlobalResilienceModeltoState(ctx context.Context, respData interface{}, data *NextGlobalResiliencyResourceModel) {
-	tflog.Info(ctx, fmt.Sprintf("GlobalResilienceModeltoState \t name: %+v", respData.(map[string]interface{})["name"].(string)))
-	data.Name = types.StringValue(respData.(map[string]interface{})["name"].(string))
-	data.DNSListenerName = types.StringValue(respData.(map[string]interface{})["dns_listener_name"].(string))
-	data.DNSListenerPort = types.Int64Value(int64(respData.(map[string]interface{})["dns_listener_port"].(float64)))
-	data.Id = types.StringValue(respData.(map[string]interface{})["id"].(string))
+// GlobalResilienceModeltoState sets data from the group respData. Instances keep the order they have in data,
+// instances only known to CM are added at the end
+func (r *NextGlobalResiliencyResource) GlobalResilienceModeltoState(ctx context.Context, respData map[string]interface{}, data *NextGlobalResiliencyResourceModel) {
+	tflog.Info(ctx, fmt.Sprintf("GlobalResilienceModeltoState \t name: %+v", respData["name"]))
+	data.Name = types.StringValue(grString(respData, "name"))
+	data.DNSListenerName = types.StringValue(grString(respData, "dns_listener_name"))
+	if port, ok := respData["dns_listener_port"].(float64); ok {
+		data.DNSListenerPort = types.Int64Value(int64(port))
+	}
+	data.Id = types.StringValue(grString(respData, "id"))
+	data.Status = types.StringValue(grString(respData, "status"))
+	data.Health = types.StringValue(grString(respData, "health"))
 
-	var instanceList []Instance
-	for _, instance := range respData.(map[string]interface{})["instances"].([]interface{}) {
+	position := make(map[string]int)
+	for i, instance := range data.Instances {
+		position[instance.Address.ValueString()] = i
+	}
+	instances, _ := respData["instances"].([]interface{})
+	instanceList := make([]Instance, len(data.Instances))
+	found := make([]bool, len(data.Instances))
+	var added []Instance
+	var statusList, addedStatus []GlobalResiliencyStatus
+	statusByAddress := make(map[string]GlobalResiliencyStatus)
+	for _, val := range instances {
+		instance, ok := val.(map[string]interface{})
+		if !ok { // coverage-ignore
+			continue
+		}
 		var i Instance
-		i.Hostname = types.StringValue(instance.(map[string]interface{})["hostname"].(string))
-		i.Address = types.StringValue(instance.(map[string]interface{})["address"].(string))
-		i.DNSListenerAddress = types.StringValue(instance.(map[string]interface{})["dns_listener_address"].(string))
-		i.GroupSyncAddress = types.StringValue(instance.(map[string]interface{})["group_sync_address"].(string))
-
-		instanceList = append(instanceList, i)
+		i.Hostname = types.StringValue(grString(instance, "hostname"))
+		i.Address = types.StringValue(grString(instance, "address"))
+		i.DNSListenerAddress = types.StringValue(grString(instance, "dns_listener_address"))
+		i.GroupSyncAddress = types.StringValue(grString(instance, "group_sync_address"))
+		syncStatus := grString(instance, "sync_status")
+		if syncStatus == "" {
+			syncStatus = grString(instance, "status")
+		}
+		status := GlobalResiliencyStatus{
+			Hostname:          i.Hostname,
+			Address:           i.Address,
+			SyncStatus:        types.StringValue(syncStatus),
+			DNSListenerHealth: types.StringValue(grString(instance, "health")),
+		}
+		if idx, ok := position[i.Address.ValueString()]; ok && !found[idx] {
+			instanceList[idx] = i
+			found[idx] = true
+			statusByAddress[i.Address.ValueString()] = status
+			continue
+		}
+		added = append(added, i)
+		addedStatus = append(addedStatus, status)
 	}
-	data.Instances = instanceList
+	var kept []Instance
+	for idx, instance := range instanceList {
+		if !found[idx] {
+			// removed from the group outside of terraform
+			continue
+		}
+		kept = append(kept, instance)
+		statusList = append(statusList, statusByAddress[instance.Address.ValueString()])
+	}
+	data.Instances = append(kept, added...)
+	data.InstanceStatus = append(statusList, addedStatus...)
 }
 
 func (r *NextGlobalResiliencyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var resCfg *NextGlobalResiliencyResourceModel
+	var resCfg, stateCfg *NextGlobalResiliencyResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateCfg)...)
 
 	if resp.Diagnostics.HasError() { // coverage-ignore
 		return
@@ -228,18 +336,28 @@ func (r *NextGlobalResiliencyResource) Update(ctx context.Context, req resource.
 	tflog.Info(ctx, fmt.Sprintf("[UPDATE] Updating Global Resiliency Group: %s", resCfg.Name.ValueString()))
 
 	reqDraft := getGlobalResiliencyRequestDraft(ctx, resCfg)
-	reqDraft.Id = resCfg.Id.ValueString()
-
-	tflog.Info(ctx, "[UPDATE] Updating Global Resiliency Group")
+	reqDraft.Id = stateCfg.Id.ValueString()
+	stateDraft := getGlobalResiliencyRequestDraft(ctx, stateCfg)
+	stateDraft.Id = stateCfg.Id.ValueString()
+
+	if reflect.DeepEqual(reqDraft, stateDraft) {
+		// only the timeout changed, there is nothing to update on CM
+		resCfg.Id = stateCfg.Id
+		resCfg.Status = stateCfg.Status
+		resCfg.Health = stateCfg.Health
+		resCfg.InstanceStatus = stateCfg.InstanceStatus
+		resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
+		return
+	}
 	tflog.Info(ctx, fmt.Sprintf("[UPDATE] :%+v\n", reqDraft))
 
-	id, err := r.client.PostGlobalResiliencyGroup("PUT", reqDraft)
-	if err != nil { // coverage-ignore
+	grData, err := postGlobalResiliencyGroup(r.client, "PUT", reqDraft, resCfg.Timeout.ValueInt64())
+	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Failed to Update Global Resiliency Group, got error: %s", err))
 		return
 	}
-	tflog.Info(ctx, fmt.Sprintf("[Update] id:%+v\n", id))
-	resCfg.Id = types.StringValue(id)
+	tflog.Info(ctx, fmt.Sprintf("[Update] id:%+v\n", grData["id"]))
+	r.GlobalResilienceModeltoState(ctx, grData, resCfg)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &resCfg)...)
 }
 
@@ -254,7 +372,7 @@ func (r *NextGlobalResiliencyResource) Delete(ctx context.Context, req resource.
 
 	tflog.Info(ctx, fmt.Sprintf("Deleting Global Resiliency Group : %s", id))
 
-	err := r.client.DeleteGlobalResiliencyGroup(id)
+	err := deleteGlobalResiliencyGroup(r.client, id, stateCfg.Timeout.ValueInt64())
 	if err != nil { // coverage-ignore
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to Delete Global Resiliency Group, got error: %s", err))
 		return
@@ -288,3 +406,8 @@ func getGlobalResiliencyRequestDraft(ctx context.Context, data *NextGlobalResili
 	tflog.Info(ctx, fmt.Sprintf("globalresilienceReqDraft:%+v\n", globalresilienceReqDraft))
 	return &globalresilienceReqDraft
 }
+
+func grString(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
@@ -1,10 +1,14 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -150,11 +154,19 @@ func TestUnitCMGlobalResiliencyCreateUnitTC2Resource(t *testing.T) {
 					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "instances.0.dns_listener_address", "2.2.2.3"),
 					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "instances.0.group_sync_address", "10.10.1.2/24"),
 					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "instances.0.hostname", "big-ip-next"),
+					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "timeout", "300"),
+					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "status", "DEPLOYED"),
+					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "health", "GOOD"),
+					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "instance_status.#", "1"),
+					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "instance_status.0.dns_listener_health", "GOOD"),
 				),
 			},
 			{
 				Config: testAccNextCMGlobalResiliencyResourceUpdateConfig,
 				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "id", "efcd6a7d-b3e3-4220-9e08-c9604ff9222e"),
+					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "instance_status.#", "2"),
+					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "instance_status.1.address", "10.144.140.81"),
 					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "name", "sample4"),
 					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "dns_listener_name", "dln"),
 					resource.TestCheckResourceAttr("bigipnext_cm_global_resiliency.sample4", "dns_listener_port", "10"),
@@ -169,6 +181,71 @@ func TestUnitCMGlobalResiliencyCreateUnitTC2Resource(t *testing.T) {
 	})
 }
 
+func TestUnitCMGlobalResiliencyCreateUnitTC3Resource(t *testing.T) {
+	testAccPreUnitCheck(t)
+	mux.HandleFunc("/api/login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{
+			"access_token": "Zj7421sAIkQF4YeRfKdMvQv+rJ1BvoWAFAycmKkQDTmxpMj5vK4o79Imsed6KBVzOxoeHRGwb08wEu7rrdeRU9HXXmukeNyaFRyXfYdxWyd2GNRYY+uqlFHiNb+2kr1UC114AFv65rRZ7tplfpFcJL39ETxSs1vjhcsBT+BClEEUP48fuYQv3htSQvNbs82i/DHYU9FYn2diUuuoOVuPhHj81Q7/Rk5FFea1NA0ahYO7GHckAtFWM/UlPgA9yyDFQ6dzyX6OuE9eppR+6/VY1t55oPxFMFdL0wkq8aulGxFWnHwgJ6EYx5KkuQvIDQOguXAb7+C+ffH2fWWh7QPnCQjddVssrbwUpbXZDgMptSyOWul6MudTVAbHfyJNMxaj159HJUv/NhrGnfu1S7A9++aYnTPJsGgqSXnASkNOqOiZr/wiRyCxzx9VF1kqgzCN8Mc+U8y2EHDveix7nF3BiQtIneYrt2ycGlqZFXkfRnQCYiOOWcAvvz2eTKYoZOPPXU9TCI4WzWnOKCGQYYRvt2uy74IOeBSexMt03EU3GA==",
+			"refresh_token": "Zj7421sAIkQF4YeRfKdMvQv+rJ1BvoWAFAycmKkQDTmxpMj5vK4o79Imsed6KBVzOxoeHRGwb08wEu7rrdeRU9HXXmukeNyaFRyXfYdxWyd2GNRYY+uqlBngG6u2kr1UC114AFv65rRZ7tplfpFcJL39ETxSs1vjhcsBT+BClEEUP48fuYQv3htSQvNbs82i/DHYU9FYn2diUuuoOVuPhHj81Q7/Rk5FFea1NA0ahYDmD3AoAtFVMK0zBABy3A7NRadzyXCPglteppR9+fRY18xtnsZHJW1LnwoE2PKlHC9WnHwgJ6EYx5KkuQvIDQOguXAb7+C+ffH2fWWh7QPnCQjddVssrbwUpbXZDgMptSyOWul6MudTVAbHfyJNMxaj159HJUv/NhrGnfu1S7A9++aYnTPJsGgqSVvAXU9Hk6mAq6cvVQKFw2tCK3Vt6SS0tpsHJ46/BiXwFyEQs2fuxx52tY4Bs3OoNlbkVTcfQFYMRA7QXvQA+QeHQ60BS+H8EFd6L6sU6UP1LEKDfYNJH3fUAVrsmPgBN5H8G67wTg==",
+			"user_id": "6dd0d482-267e-4916-b524-ee8e5dd1c78"
+		}`)
+	})
+	mux.HandleFunc("/api/v1/spaces/default/gslb/gr-groups", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"_links":{"self":{"href":"/api/v1/spaces/default/gslb/gr-groups/"}},"path":"/v1/gslb/gr-groups/efcd6a7d-b3e3-4220-9e08-c9604ff9222e"}`)
+	})
+	mux.HandleFunc("/api/v1/spaces/default/gslb/gr-groups/efcd6a7d-b3e3-4220-9e08-c9604ff9222e", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"dns_listener_name":"dln","dns_listener_port":10,"health":"UNKNOWN","id":"efcd6a7d-b3e3-4220-9e08-c9604ff9222e","instances":[],"name":"sample4","protocols":["tcp"],"status":"FAILED"}`)
+	})
+	mux.HandleFunc("/api/alert/v1/alerts/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"_embedded":{"alerts":[]},"count":0}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNextCMGlobalResiliencyResourceTimeoutConfig,
+				ExpectError: regexp.MustCompile("Invalid Attribute Value"),
+			},
+			{
+				Config:      testAccNextCMGlobalResiliencyResourceConfig,
+				ExpectError: regexp.MustCompile("no active alert on CM describes the failure"),
+			},
+		},
+	})
+}
+
+func TestUnitCMGlobalResiliencyInstanceOrder(t *testing.T) {
+	data := &NextGlobalResiliencyResourceModel{
+		Instances: []Instance{
+			{Address: types.StringValue("10.144.140.81")},
+			{Address: types.StringValue("10.145.71.115")},
+			{Address: types.StringValue("10.146.194.171")},
+		},
+	}
+	var grData map[string]interface{}
+	_ = json.Unmarshal([]byte(`{"id":"efcd6a7d-b3e3-4220-9e08-c9604ff9222e","name":"sample4","status":"DEPLOYED","health":"GOOD","instances":[
+		{"hostname":"big-ip-next","address":"10.145.71.115","health":"GOOD","sync_status":"IN_SYNC"},
+		{"hostname":"demovm01-lab-local","address":"10.144.140.81","health":"CRITICAL","sync_status":"SYNCING"},
+		{"hostname":"demovm02-lab-local","address":"10.146.194.172","health":"GOOD"}]}`), &grData)
+	(&NextGlobalResiliencyResource{}).GlobalResilienceModeltoState(context.Background(), grData, data)
+	var addresses []string
+	for _, instance := range data.Instances {
+		addresses = append(addresses, instance.Address.ValueString())
+	}
+	if fmt.Sprint(addresses) != "[10.144.140.81 10.145.71.115 10.146.194.172]" {
+		t.Errorf("unexpected instances order: %v", addresses)
+	}
+	if data.InstanceStatus[0].DNSListenerHealth.ValueString() != "CRITICAL" || data.InstanceStatus[0].SyncStatus.ValueString() != "SYNCING" {
+		t.Errorf("unexpected instance status: %+v", data.InstanceStatus[0])
+	}
+}
+
 const testAccNextCMGlobalResiliencyResourceConfig = `
 resource "bigipnext_cm_global_resiliency" "sample4" {
 	name      = "sample4"
@@ -206,3 +283,20 @@ resource "bigipnext_cm_global_resiliency" "sample4" {
 		}
 	]
 }`
+
+const testAccNextCMGlobalResiliencyResourceTimeoutConfig = `
+resource "bigipnext_cm_global_resiliency" "sample4" {
+	name      = "sample4"
+	dns_listener_name = "dln"
+	dns_listener_port = 10
+	protocols = ["tcp"]
+	instances = [
+		{
+			address = "10.145.71.115"
+			dns_listener_address = "2.2.2.3"
+			group_sync_address = "10.10.1.2/24"
+			hostname = "big-ip-next"
+		}
+	]
+	timeout = 0
+}`
@@ -2,21 +2,38 @@ package provider
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	bigipnextsdk "gitswarm.f5net.com/terraform-providers/bigipnext"
+	"golang.org/x/crypto/pkcs12"
 )
 
 var (
 	_ resource.Resource                = &NextCMCertificateResource{}
 	_ resource.ResourceWithImportState = &NextCMCertificateResource{}
+	_ resource.ResourceWithModifyPlan  = &NextCMImportCertificateResource{}
 	// mutex sync.Mutex
 )
 
@@ -34,17 +51,39 @@ type NextCMImportCertificateResourceModel struct {
 	CertPassphrase types.String `tfsdk:"cert_passphrase"`
 	KeyText        types.String `tfsdk:"key_text"`
 	CertText       types.String `tfsdk:"cert_text"`
+	KeyFile        types.String `tfsdk:"key_file"`
+	CertFile       types.String `tfsdk:"cert_file"`
+	ChainText      types.String `tfsdk:"chain_text"`
+	ChainFile      types.String `tfsdk:"chain_file"`
+	Pkcs12Bundle   types.String `tfsdk:"pkcs12_bundle"`
+	Pkcs12File     types.String `tfsdk:"pkcs12_file"`
+	FileHash       types.String `tfsdk:"file_hash"`
 	ImportType     types.String `tfsdk:"import_type"`
 	Id             types.String `tfsdk:"id"`
 }
 
+// importCertificateMaterial is the certificate, key, chain or PKCS#12 bundle to import, read from the inline
+// attributes or the files
+type importCertificateMaterial struct {
+	cert   string
+	key    string
+	chain  string
+	pkcs12 string
+	// files is the content of the files the material was read from, used to detect changes of the files
+	files []byte
+}
+
 func (r *NextCMImportCertificateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_cm_import_certitficate"
 }
 
 func (r *NextCMImportCertificateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used to manage(CRUD) certificate management resources onto BIG-IP Next CM.",
+		MarkdownDescription: "Resource used to manage(CRUD) certificate management resources onto BIG-IP Next CM.\n\n" +
+			"The certificate and key are given as PEM, inline or from files, or as a PKCS#12 bundle. " +
+			"The key is validated to match the certificate and the chain to be ordered from the certificate to the root at plan time. " +
+			"A PKCS#12 bundle is decrypted with `cert_passphrase` and validated the same way, unless it is encrypted with an algorithm such as AES " +
+			"which is only validated by Central Manager on import.",
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Required:            true,
@@ -59,19 +98,69 @@ func (r *NextCMImportCertificateResource) Schema(ctx context.Context, req resour
 			},
 			"cert_passphrase": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "cert passphrase, A passphrase is a word or phrase that protects files ",
+				MarkdownDescription: "cert passphrase, A passphrase is a word or phrase that protects files, also the passphrase of the PKCS#12 bundle.",
 			},
 			"key_text": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "key content",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("key_file"), path.MatchRoot("pkcs12_bundle"), path.MatchRoot("pkcs12_file")),
+				},
 			},
 			"cert_text": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "cert content",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("cert_file"), path.MatchRoot("pkcs12_bundle"), path.MatchRoot("pkcs12_file")),
+				},
+			},
+			"key_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of the PEM file with the key, instead of `key_text`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("pkcs12_bundle"), path.MatchRoot("pkcs12_file")),
+				},
+			},
+			"cert_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of the PEM file with the certificate, instead of `cert_text`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("pkcs12_bundle"), path.MatchRoot("pkcs12_file")),
+				},
+			},
+			"chain_text": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "PEM of the intermediate certificates, ordered from the issuer of the certificate up to the root. The chain is imported along with the certificate.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("chain_file"), path.MatchRoot("pkcs12_bundle"), path.MatchRoot("pkcs12_file")),
+				},
+			},
+			"chain_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of the PEM file with the intermediate certificates, instead of `chain_text`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("pkcs12_bundle"), path.MatchRoot("pkcs12_file")),
+				},
+			},
+			"pkcs12_bundle": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Base64 encoded PKCS#12 bundle with the certificate, key and chain, such as the content of a `.pfx` file. The passphrase of the bundle is given by `cert_passphrase`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("pkcs12_file")),
+				},
+			},
+			"pkcs12_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Path of the PKCS#12 bundle, such as a `.pfx` file, instead of `pkcs12_bundle`.",
+			},
+			"file_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 hash of the content of the files given by `cert_file`, `key_file`, `chain_file` and `pkcs12_file`, a change of the content is imported on apply.",
 			},
 			"import_type": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Import Type, Value can be `PKCS12`",
+				MarkdownDescription: "Import Type, Value can be `PKCS12`. Set to `PKCS12` when `pkcs12_bundle` or `pkcs12_file` is given.",
 			},
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -88,6 +177,39 @@ func (r *NextCMImportCertificateResource) Configure(ctx context.Context, req res
 	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
 }
 
+func (r *NextCMImportCertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan *NextCMImportCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
+	for _, value := range []types.String{plan.KeyText, plan.CertText, plan.KeyFile, plan.CertFile, plan.ChainText, plan.ChainFile, plan.Pkcs12Bundle, plan.Pkcs12File, plan.CertPassphrase, plan.ImportType} {
+		if value.IsUnknown() {
+			// the material is validated once all of it is known
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_hash"), types.StringUnknown())...)
+			return
+		}
+	}
+	material, diags := readImportCertificateMaterial(plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateImportCertificateMaterial(plan, material)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	fileHash := types.StringNull()
+	if material.files != nil {
+		sum := sha256.Sum256(material.files)
+		fileHash = types.StringValue(hex.EncodeToString(sum[:]))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_hash"), fileHash)...)
+}
+
 func (r *NextCMImportCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var resCfg *NextCMImportCertificateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
@@ -96,7 +218,11 @@ func (r *NextCMImportCertificateResource) Create(ctx context.Context, req resour
 	}
 	tflog.Info(ctx, fmt.Sprintf("[CREATE] NextCMImportCertificateResource:%+v\n", resCfg.Name.ValueString()))
 
-	reqDraft := getImportCertificateRequestDraft(ctx, resCfg)
+	reqDraft, diags := getImportCertificateRequestDraft(ctx, resCfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
 	reqDraft.Name = resCfg.Name.ValueString()
 	// tflog.Info(ctx, fmt.Sprintf("[CREATE] :%+v\n", reqDraft))
 
@@ -139,7 +265,20 @@ func (r *NextCMImportCertificateResource) Read(ctx context.Context, req resource
 
 func (r *NextCMImportCertificateResource) CertificateResourceModeltoState(ctx context.Context, keycertData interface{}, respData interface{}, data *NextCMImportCertificateResourceModel) {
 	data.Name = types.StringValue(respData.(map[string]interface{})["name"].(string))
-	data.CertText = types.StringValue(keycertData.(map[string]interface{})["cert_data"].(string))
+	// the certificate read from CM is only compared with the inline certificate, it is not kept when the
+	// certificate is given by a file or a PKCS#12 bundle
+	if data.CertFile.IsNull() && data.Pkcs12Bundle.IsNull() && data.Pkcs12File.IsNull() {
+		certData := keycertData.(map[string]interface{})["cert_data"].(string)
+		chain := data.ChainText.ValueString()
+		if !data.ChainFile.IsNull() {
+			chainData, err := os.ReadFile(data.ChainFile.ValueString())
+			if err != nil {
+				tflog.Warn(ctx, fmt.Sprintf("Unable to read chain file %s: %s", data.ChainFile.ValueString(), err))
+			}
+			chain = string(chainData)
+		}
+		data.CertText = types.StringValue(trimCertificateChain(certData, chain))
+	}
 	// data.KeyText = types.StringValue(keycertData.(map[string]interface{})["key_data"].(string))
 }
 
@@ -152,7 +291,11 @@ func (r *NextCMImportCertificateResource) Update(ctx context.Context, req resour
 	}
 	tflog.Info(ctx, fmt.Sprintf("[UPDATE]Posting Certificate: %s", resCfg.Name.ValueString()))
 
-	reqDraft := getImportCertificateRequestDraft(ctx, resCfg)
+	reqDraft, diags := getImportCertificateRequestDraft(ctx, resCfg)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() { // coverage-ignore
+		return
+	}
 	reqDraft.Id = resCfg.Id.ValueString()
 
 	tflog.Info(ctx, "[UPDATE] Posting Certificate")
@@ -190,14 +333,288 @@ func (r *NextCMImportCertificateResource) ImportState(ctx context.Context, req r
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func getImportCertificateRequestDraft(ctx context.Context, data *NextCMImportCertificateResourceModel) *bigipnextsdk.ImportCertificateRequestDraft {
+func getImportCertificateRequestDraft(ctx context.Context, data *NextCMImportCertificateResourceModel) (*bigipnextsdk.ImportCertificateRequestDraft, diag.Diagnostics) {
+	material, diags := readImportCertificateMaterial(data)
+	if diags.HasError() {
+		return nil, diags
+	}
 	var certificateReqDraft bigipnextsdk.ImportCertificateRequestDraft
 	certificateReqDraft.KeyPassphrase = data.KeyPassphrase.ValueString()
 	certificateReqDraft.CertPassphrase = data.CertPassphrase.ValueString()
-	certificateReqDraft.KeyText = data.KeyText.ValueString()
-	certificateReqDraft.CertText = data.CertText.ValueString()
+	certificateReqDraft.KeyText = material.key
+	certificateReqDraft.CertText = material.cert
+	if material.chain != "" {
+		certificateReqDraft.CertText = strings.TrimRight(material.cert, "\n") + "\n" + material.chain
+	}
 	certificateReqDraft.ImportType = data.ImportType.ValueString()
+	if material.pkcs12 != "" {
+		certificateReqDraft.CertText = material.pkcs12
+		certificateReqDraft.ImportType = "PKCS12"
+	}
 
-	tflog.Info(ctx, fmt.Sprintf("certificateReqDraft:%+v\n", certificateReqDraft))
-	return &certificateReqDraft
+	tflog.Info(ctx, fmt.Sprintf("certificateReqDraft name:%+v import type:%+v\n", data.Name.ValueString(), certificateReqDraft.ImportType))
+	return &certificateReqDraft, diags
+}
+
+// readImportCertificateMaterial reads the certificate, key, chain and PKCS#12 bundle from the inline attributes or
+// the files
+func readImportCertificateMaterial(data *NextCMImportCertificateResourceModel) (*importCertificateMaterial, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	material := &importCertificateMaterial{
+		cert:   data.CertText.ValueString(),
+		key:    data.KeyText.ValueString(),
+		chain:  data.ChainText.ValueString(),
+		pkcs12: strings.Join(strings.Fields(data.Pkcs12Bundle.ValueString()), ""),
+	}
+	readFile := func(attribute string, file types.String) string {
+		if file.IsNull() {
+			return ""
+		}
+		content, err := os.ReadFile(file.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Unable to Read File", fmt.Sprintf("Unable to read %s, got error: %s", file.ValueString(), err))
+			return ""
+		}
+		material.files = append(material.files, content...)
+		return string(content)
+	}
+	if content := readFile("cert_file", data.CertFile); content != "" {
+		material.cert = content
+	}
+	if content := readFile("key_file", data.KeyFile); content != "" {
+		material.key = content
+	}
+	if content := readFile("chain_file", data.ChainFile); content != "" {
+		material.chain = content
+	}
+	if content := readFile("pkcs12_file", data.Pkcs12File); content != "" {
+		material.pkcs12 = base64.StdEncoding.EncodeToString([]byte(content))
+	}
+	if material.cert == "" && material.pkcs12 == "" && !diags.HasError() {
+		diags.AddError("Missing Certificate", "One of `cert_text`, `cert_file`, `pkcs12_bundle` or `pkcs12_file` must be set")
+	}
+	return material, diags
+}
+
+// validateImportCertificateMaterial validates the key matches the certificate and every certificate of the chain is
+// issued by the next one, for PEM material and PKCS#12 bundles. Encrypted keys are validated by Central Manager on import.
+func validateImportCertificateMaterial(data *NextCMImportCertificateResourceModel, material *importCertificateMaterial) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if material.pkcs12 != "" {
+		bundle, err := base64.StdEncoding.DecodeString(material.pkcs12)
+		if err != nil {
+			diags.AddAttributeError(path.Root("pkcs12_bundle"), "Invalid PKCS#12 Bundle", fmt.Sprintf("The PKCS#12 bundle is not base64 encoded: %s", err))
+			return diags
+		}
+		bundlePath := path.Root("pkcs12_bundle")
+		if !data.Pkcs12File.IsNull() {
+			bundlePath = path.Root("pkcs12_file")
+		}
+		return validatePkcs12Bundle(bundle, data.CertPassphrase.ValueString(), bundlePath)
+	}
+	if data.ImportType.ValueString() == "PKCS12" {
+		// a PKCS#12 bundle given by cert_text
+		return diags
+	}
+	sourcePath := func(text, file string, fileValue types.String) path.Path {
+		if !fileValue.IsNull() {
+			return path.Root(file)
+		}
+		return path.Root(text)
+	}
+	certPath := sourcePath("cert_text", "cert_file", data.CertFile)
+	chainPath := sourcePath("chain_text", "chain_file", data.ChainFile)
+	keyPath := sourcePath("key_text", "key_file", data.KeyFile)
+	certs, err := parseCertificates(material.cert)
+	if err != nil || len(certs) == 0 {
+		diags.AddAttributeError(certPath, "Invalid Certificate", fmt.Sprintf("The certificate is not a PEM encoded certificate: %v", err))
+		return diags
+	}
+	chain, err := parseCertificates(material.chain)
+	if err != nil {
+		diags.AddAttributeError(chainPath, "Invalid Certificate Chain", fmt.Sprintf("The chain is not PEM encoded certificates: %s", err))
+		return diags
+	}
+	// a certificate file may hold the certificate followed by its chain
+	certs = append(certs, chain...)
+	for i := 0; i < len(certs)-1; i++ {
+		if err := certs[i].CheckSignatureFrom(certs[i+1]); err != nil {
+			diags.AddAttributeError(chainPath, "Invalid Certificate Chain",
+				fmt.Sprintf("Certificate %q is not issued by the next certificate %q of the chain, the chain must be ordered from the issuer of the certificate up to the root: %s",
+					certs[i].Subject.String(), certs[i+1].Subject.String(), err))
+			return diags
+		}
+	}
+	if material.key == "" {
+		return diags
+	}
+	key, err := parsePrivateKey(material.key)
+	if err != nil {
+		diags.AddAttributeError(keyPath, "Invalid Key", fmt.Sprintf("The key is not a PEM encoded private key: %s", err))
+		return diags
+	}
+	if key == nil {
+		// encrypted key
+		return diags
+	}
+	if !keyMatchesCertificate(key, certs[0]) {
+		diags.AddAttributeError(keyPath, "Key Does Not Match Certificate",
+			fmt.Sprintf("The key is not the private key of the certificate %q", certs[0].Subject.String()))
+	}
+	return diags
+}
+
+// validatePkcs12Bundle decrypts the PKCS#12 bundle with the passphrase and validates the key matches one of its
+// certificates and the other certificates form the chain of that certificate. Bundles encrypted with algorithms the
+// decoder does not implement, such as AES, are only validated by Central Manager on import, with a warning.
+func validatePkcs12Bundle(bundle []byte, passphrase string, bundlePath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	blocks, err := pkcs12.ToPEM(bundle, passphrase)
+	var notImplemented pkcs12.NotImplementedError
+	if errors.As(err, &notImplemented) {
+		diags.AddAttributeWarning(bundlePath, "PKCS#12 Bundle Not Validated",
+			fmt.Sprintf("The PKCS#12 bundle could not be validated locally, it is validated by Central Manager on import: %s", err))
+		return diags
+	}
+	if errors.Is(err, pkcs12.ErrIncorrectPassword) {
+		diags.AddAttributeError(path.Root("cert_passphrase"), "Invalid PKCS#12 Passphrase", "The PKCS#12 bundle cannot be decrypted with cert_passphrase.")
+		return diags
+	}
+	if err != nil {
+		diags.AddAttributeError(bundlePath, "Invalid PKCS#12 Bundle", fmt.Sprintf("The PKCS#12 bundle cannot be decoded: %s", err))
+		return diags
+	}
+	var certs []*x509.Certificate
+	var key crypto.Signer
+	for _, block := range blocks {
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				diags.AddAttributeError(bundlePath, "Invalid PKCS#12 Bundle", fmt.Sprintf("The PKCS#12 bundle holds an invalid certificate: %s", err))
+				return diags
+			}
+			certs = append(certs, cert)
+		case "PRIVATE KEY":
+			// PKCS #1 encoded for RSA and SEC 1 encoded for ECDSA keys, see pkcs12.ToPEM
+			if rsaKey, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+				key = rsaKey
+			} else if ecKey, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+				key = ecKey
+			} else {
+				diags.AddAttributeError(bundlePath, "Invalid PKCS#12 Bundle", fmt.Sprintf("The PKCS#12 bundle holds an invalid key: %s", err))
+				return diags
+			}
+		}
+	}
+	if len(certs) == 0 {
+		diags.AddAttributeError(bundlePath, "Invalid PKCS#12 Bundle", "The PKCS#12 bundle holds no certificate")
+		return diags
+	}
+	leaf := 0
+	if key != nil {
+		leaf = -1
+		for i, cert := range certs {
+			if keyMatchesCertificate(key, cert) {
+				leaf = i
+				break
+			}
+		}
+		if leaf < 0 {
+			diags.AddAttributeError(bundlePath, "Key Does Not Match Certificate",
+				fmt.Sprintf("The key of the PKCS#12 bundle is not the private key of its certificate %q", certs[0].Subject.String()))
+			return diags
+		}
+	}
+	// the certificates of a bundle are not ordered, the chain is built from the issuers of the certificate
+	chain := []*x509.Certificate{certs[leaf]}
+	rest := append(append([]*x509.Certificate{}, certs[:leaf]...), certs[leaf+1:]...)
+	for len(rest) > 0 {
+		issuer := -1
+		for i, cert := range rest {
+			if chain[len(chain)-1].CheckSignatureFrom(cert) == nil {
+				issuer = i
+				break
+			}
+		}
+		if issuer < 0 {
+			diags.AddAttributeError(bundlePath, "Invalid Certificate Chain",
+				fmt.Sprintf("Certificate %q of the PKCS#12 bundle is not part of the chain of the certificate %q", rest[0].Subject.String(), certs[leaf].Subject.String()))
+			return diags
+		}
+		chain = append(chain, rest[issuer])
+		rest = append(rest[:issuer], rest[issuer+1:]...)
+	}
+	return diags
+}
+
+// keyMatchesCertificate returns whether the key is the private key of the certificate
+func keyMatchesCertificate(key crypto.Signer, cert *x509.Certificate) bool {
+	publicKey, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && publicKey.Equal(key.Public())
+}
+
+// parseCertificates parses the PEM encoded certificates
+func parseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %s", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 && strings.TrimSpace(data) != "" {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// parsePrivateKey parses the PEM encoded private key, the key is nil when it is encrypted
+func parsePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded key found")
+	}
+	if block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return nil, nil
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %T", key) // coverage-ignore
+}
+
+// trimCertificateChain removes the chain appended to the certificate on import, so the certificate read from
+// Central Manager compares with the configured certificate
+func trimCertificateChain(certData, chain string) string {
+	chain = strings.TrimSpace(chain)
+	if chain == "" || !strings.HasSuffix(strings.TrimSpace(certData), chain) {
+		return certData
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(certData), chain)) + "\n"
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -22,6 +25,7 @@ import (
 var (
 	_ resource.Resource                = &NextDeployF5osResource{}
 	_ resource.ResourceWithImportState = &NextDeployF5osResource{}
+	_ resource.ResourceWithModifyPlan  = &NextDeployF5osResource{}
 )
 
 func NewNextDeployF5osResource() resource.Resource {
@@ -66,7 +70,8 @@ func (r *NextDeployF5osResource) Metadata(ctx context.Context, req resource.Meta
 
 func (r *NextDeployF5osResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource used to Deploy New `BIG-IP-Next` Instance on F5OS platforms like rSeries/velos using Next Image available on F5OS Platform",
+		MarkdownDescription: "Resource used to Deploy New `BIG-IP-Next` Instance on F5OS platforms like rSeries/velos using Next Image available on F5OS Platform\n\n" +
+			"The tenant image, tenant deployment file, VLANs, slots and CPU/disk capacity are validated against the F5OS platform at plan time, see the `bigipnext_cm_f5os_images`, `bigipnext_cm_f5os_vlans` and `bigipnext_cm_f5os_slots` data sources. When the provider or the inventory of the platform cannot be read, or a slot does not report its capacity, the plan warns and the check is skipped.",
 		Attributes: map[string]schema.Attribute{
 			"f5os_provider": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
@@ -175,6 +180,54 @@ func (r *NextDeployF5osResource) Configure(ctx context.Context, req resource.Con
 	r.client, resp.Diagnostics = toBigipNextCMProvider(req.ProviderData)
 }
 
+// ModifyPlan validates a new instance against the inventory of the F5OS platform, so a typo in the tenant image,
+// the VLANs or the slots fails the plan instead of the deployment. The validation is skipped with a warning when the
+// inventory cannot be read
+func (r *NextDeployF5osResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.State.Raw.IsNull() || r.client == nil {
+		return
+	}
+	var planCfg NextDeployF5osResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &planCfg)...)
+	if resp.Diagnostics.HasError() || planCfg.F5OSProvider.IsUnknown() || planCfg.F5OSInstance.IsUnknown() { // coverage-ignore
+		return
+	}
+	var providerModel F5OSProviderModel
+	resp.Diagnostics.Append(planCfg.F5OSProvider.As(ctx, &providerModel, basetypes.ObjectAsOptions{})...)
+	var instanceModel F5OSInstanceModel
+	resp.Diagnostics.Append(planCfg.F5OSInstance.As(ctx, &instanceModel, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() || providerModel.ProviderName.IsUnknown() || providerModel.ProviderType.IsUnknown() { // coverage-ignore
+		return
+	}
+
+	providers, err := listCMProviders(r.client)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to validate F5OS inventory", fmt.Sprintf("Unable to read providers, got error: %s", err))
+		return
+	}
+	provider, err := cmProviderByName(providers, providerModel.ProviderName.ValueString(), strings.ToUpper(providerModel.ProviderType.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to validate F5OS inventory", fmt.Sprintf("Unable to find provider, got error: %s", err))
+		return
+	}
+	images, err := getF5OSTenantImages(r.client, provider.Id)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Unable to validate F5OS inventory", fmt.Sprintf("Unable to read F5OS tenant images, got error: %s", err))
+		return
+	}
+	vlans, err := getF5OSVlans(r.client, provider.Id)
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddWarning("Unable to validate F5OS inventory", fmt.Sprintf("Unable to read F5OS VLANs, got error: %s", err))
+		return
+	}
+	nodes, err := getF5OSNodes(r.client, provider.Id)
+	if err != nil { // coverage-ignore
+		resp.Diagnostics.AddWarning("Unable to validate F5OS inventory", fmt.Sprintf("Unable to read F5OS slots, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(f5osInventoryDiagnostics(ctx, providerModel.ProviderType.ValueString(), instanceModel, images, vlans, nodes)...)
+}
+
 func (r *NextDeployF5osResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var resCfg *NextDeployF5osResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &resCfg)...)
@@ -423,3 +476,104 @@ func f5osVelosConfig(ctx context.Context, data *NextDeployF5osResourceModel) *bi
 	tflog.Info(ctx, fmt.Sprintf("cmReqDeviceInstance : %+v", cmReqDeviceInstance))
 	return &cmReqDeviceInstance
 }
+
+// f5osInventoryDiagnostics checks the tenant image, tenant deployment file, VLANs and slots of instance exist on the
+// F5OS platform, and the slots have the CPU cores and disk size for it. Slots which do not report their capacity are
+// warned about instead. rSeries instances run on all the nodes of the
+// appliance whatever slot_ids, VELOS instances on slot_ids only. Unknown values are not checked
+func f5osInventoryDiagnostics(ctx context.Context, providerType string, instance F5OSInstanceModel, images []f5osTenantImage, vlans []f5osVlan, nodes []f5osNode) diag.Diagnostics {
+	var diags diag.Diagnostics
+	instancePath := path.Root("instance")
+
+	var imageNames, deploymentFileNames []string
+	for _, image := range images {
+		if image.isDeploymentFile() {
+			deploymentFileNames = append(deploymentFileNames, image.Name)
+		} else {
+			imageNames = append(imageNames, image.Name)
+		}
+	}
+	if name := instance.TenantImageName; !name.IsUnknown() && !slices.Contains(imageNames, name.ValueString()) {
+		diags.AddAttributeError(instancePath.AtName("tenant_image_name"), "Tenant Image Not Found",
+			fmt.Sprintf("tenant image %q not found on the F5OS platform, existing tenant images: [%s]", name.ValueString(), strings.Join(imageNames, ", ")))
+	}
+	if name := instance.TenantDeploymentFile; !name.IsUnknown() && !slices.Contains(deploymentFileNames, name.ValueString()) {
+		diags.AddAttributeError(instancePath.AtName("tenant_deployment_file"), "Tenant Deployment File Not Found",
+			fmt.Sprintf("tenant deployment file %q not found on the F5OS platform, existing tenant deployment files: [%s]", name.ValueString(), strings.Join(deploymentFileNames, ", ")))
+	}
+
+	var vlanIDs []int64
+	var vlanNames []string
+	for _, vlan := range vlans {
+		vlanIDs = append(vlanIDs, vlan.Id)
+		vlanNames = append(vlanNames, fmt.Sprintf("%d", vlan.Id))
+	}
+	for _, vlanID := range f5osKnownInt64s(ctx, instance.VlanIDs) {
+		if !slices.Contains(vlanIDs, vlanID) {
+			diags.AddAttributeError(instancePath.AtName("vlan_ids"), "VLAN Not Found",
+				fmt.Sprintf("VLAN %d not found on the F5OS platform, existing VLANs: [%s]", vlanID, strings.Join(vlanNames, ", ")))
+		}
+	}
+
+	var slotIDs []string
+	for _, node := range nodes {
+		slotIDs = append(slotIDs, fmt.Sprintf("%d", node.Slot))
+	}
+	slotNodes := nodes
+	if providerType == "velos" {
+		slotNodes = nil
+		for _, slotID := range f5osKnownInt64s(ctx, instance.SlotIDs) {
+			found := false
+			for _, node := range nodes {
+				if node.Slot == slotID {
+					slotNodes = append(slotNodes, node)
+					found = true
+				}
+			}
+			if !found {
+				diags.AddAttributeError(instancePath.AtName("slot_ids"), "Slot Not Found",
+					fmt.Sprintf("slot %d not found on the F5OS platform, existing slots: [%s]", slotID, strings.Join(slotIDs, ", ")))
+			}
+		}
+	}
+	for _, node := range slotNodes {
+		if !node.Enabled {
+			diags.AddAttributeError(instancePath.AtName("slot_ids"), "Slot Not Enabled",
+				fmt.Sprintf("slot %d of the F5OS platform is not enabled for tenants", node.Slot))
+			continue
+		}
+		cpuCores := instance.CpuCores
+		if !cpuCores.IsUnknown() && node.CpuCoresTotal == 0 {
+			diags.AddWarning("Unable to validate F5OS capacity",
+				fmt.Sprintf("slot %d of the F5OS platform does not report its CPU cores, the free CPU cores are not checked", node.Slot))
+		} else if !cpuCores.IsUnknown() && node.CpuCoresTotal-node.CpuCoresUsed < cpuCores.ValueInt64() {
+			diags.AddAttributeError(instancePath.AtName("cpu_cores"), "Insufficient CPU Cores",
+				fmt.Sprintf("slot %d of the F5OS platform has %d free CPU cores, %d requested", node.Slot, node.CpuCoresTotal-node.CpuCoresUsed, cpuCores.ValueInt64()))
+		}
+		diskSize := instance.DiskSize
+		if !diskSize.IsUnknown() && node.DiskSizeTotal == 0 {
+			diags.AddWarning("Unable to validate F5OS capacity",
+				fmt.Sprintf("slot %d of the F5OS platform does not report its disk size, the free disk is not checked", node.Slot))
+		} else if !diskSize.IsUnknown() && node.DiskSizeTotal-node.DiskSizeUsed < diskSize.ValueInt64() {
+			diags.AddAttributeError(instancePath.AtName("disk_size"), "Insufficient Disk Size",
+				fmt.Sprintf("slot %d of the F5OS platform has %d GB of free disk, %d GB requested", node.Slot, node.DiskSizeTotal-node.DiskSizeUsed, diskSize.ValueInt64()))
+		}
+	}
+	return diags
+}
+
+// f5osKnownInt64s returns the known values of a list of numbers
+func f5osKnownInt64s(ctx context.Context, list types.List) []int64 {
+	var values []int64
+	if list.IsNull() || list.IsUnknown() {
+		return values
+	}
+	var elements []types.Int64
+	_ = list.ElementsAs(ctx, &elements, false)
+	for _, element := range elements {
+		if !element.IsNull() && !element.IsUnknown() {
+			values = append(values, element.ValueInt64())
+		}
+	}
+	return values
+}
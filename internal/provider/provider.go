@@ -193,11 +193,22 @@ func (p *BigipNextCMProvider) Resources(ctx context.Context) []func() resource.R
 		NewCMDiscoveryNextResource,
 		NewNextCMWAFPolicyResource,
 		NewNextCMWAFPolicyImportResource,
+		NewNextCMWAFPolicyAttachmentResource,
+		NewNextCMWAFSuggestionAcceptResource,
+		NewNextCMGSLBApplicationResource,
+		NewNextCMGSLBMonitorResource,
 		NewNextCMHAClusterResource,
 		NewCMNextJwtTokenResource,
 		NewCMNextLicenseActivateResource,
 		NewCMNextBootstrapResource,
 		NewCMNextUpgradeResource,
+		NewCMUpgradeResource,
+		NewCMNextHAUpgradeResource,
+		NewCMFileResource,
+		NewCMBackupResource,
+		NewCMBackupScheduleResource,
+		NewCMRestoreResource,
+		NewCMFleetBackupResource,
 		NewNextCMOnboardResource,
 	}
 }
@@ -205,6 +216,21 @@ func (p *BigipNextCMProvider) Resources(ctx context.Context) []func() resource.R
 func (p *BigipNextCMProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewDeviceInventorySource,
+		NewCMFilesDataSource,
+		NewCMBackupsDataSource,
+		NewCMLicenseTokensDataSource,
+		NewCMInstanceLicenseDataSource,
+		NewCMWAFSuggestionsDataSource,
+		NewCMWAFReportResultsDataSource,
+		NewCMWAFEventsDataSource,
+		NewCMProvidersDataSource,
+		NewCMProviderDataSource,
+		NewCMVsphereDatacentersDataSource,
+		NewCMVsphereDatacenterDataSource,
+		NewCMVsphereTemplatesDataSource,
+		NewCMF5OSImagesDataSource,
+		NewCMF5OSVlansDataSource,
+		NewCMF5OSSlotsDataSource,
 	}
 }
 
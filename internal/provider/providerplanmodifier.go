@@ -122,7 +122,7 @@ func (m as3JsonPlanModifier) MarkdownDescription(_ context.Context) string {
 }
 
 func (m as3JsonPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, res *planmodifier.StringResponse) {
-	if req.State.Raw.IsNull() {
+	if req.State.Raw.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
 		return
 	}
 	as3Statedata := []byte(req.StateValue.ValueString())
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// s3Client is a minimal client for S3 compatible object storage, requests use path style
+// addressing and are signed with AWS Signature Version 4
+type s3Client struct {
+	endpoint   *url.URL
+	bucket     string
+	region     string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+}
+
+type s3Object struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	Size         int64     `xml:"Size"`
+}
+
+type s3ListBucketResult struct {
+	Contents              []s3Object `xml:"Contents"`
+	IsTruncated           bool       `xml:"IsTruncated"`
+	NextContinuationToken string     `xml:"NextContinuationToken"`
+}
+
+func newS3Client(endpoint, bucket, region, accessKey, secretKey string) (*s3Client, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpointURL.Scheme != "http" && endpointURL.Scheme != "https" {
+		return nil, fmt.Errorf("S3 endpoint %s must use http or https", endpoint)
+	}
+	return &s3Client{
+		endpoint:   endpointURL,
+		bucket:     bucket,
+		region:     region,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		httpClient: &http.Client{Timeout: 30 * time.Minute},
+	}, nil
+}
+
+// putObject uploads the file as object key, S3 verifies the content against the signed SHA-256 checksum
+func (c *s3Client) putObject(key, filePath, sha256Hex string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest("PUT", key, nil, file, sha256Hex)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	_, err = c.do(req)
+	return err
+}
+
+// listObjects returns the objects with keys starting with prefix
+func (c *s3Client) listObjects(prefix string) ([]s3Object, error) {
+	var objects []s3Object
+	query := url.Values{}
+	query.Set("list-type", "2")
+	query.Set("prefix", prefix)
+	for {
+		req, err := c.newRequest("GET", "", query, nil, s3EmptyPayloadHash)
+		if err != nil {
+			return nil, err
+		}
+		respData, err := c.do(req)
+		if err != nil {
+			return nil, err
+		}
+		var result s3ListBucketResult
+		if err = xml.Unmarshal(respData, &result); err != nil {
+			return nil, err
+		}
+		objects = append(objects, result.Contents...)
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		query.Set("continuation-token", result.NextContinuationToken)
+	}
+}
+
+func (c *s3Client) deleteObject(key string) error {
+	req, err := c.newRequest("DELETE", key, nil, nil, s3EmptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(req)
+	return err
+}
+
+func (c *s3Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("S3 %s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, respData)
+	}
+	return respData, nil
+}
+
+const s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func (c *s3Client) newRequest(method, key string, query url.Values, body io.Reader, payloadHash string) (*http.Request, error) {
+	objectPath := "/" + c.bucket
+	if key != "" {
+		objectPath += "/" + key
+	}
+	canonicalQuery := s3CanonicalQuery(query)
+	reqURL := *c.endpoint
+	reqURL.Path = strings.TrimSuffix(c.endpoint.Path, "/") + objectPath
+	reqURL.RawPath = strings.TrimSuffix(c.endpoint.EscapedPath(), "/") + s3EscapePath(objectPath)
+	reqURL.RawQuery = canonicalQuery
+
+	req, err := http.NewRequest(method, reqURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", req.URL.Host, payloadHash, amzDate)
+	canonicalRequest := strings.Join([]string{method, reqURL.RawPath, canonicalQuery, canonicalHeaders, signedHeaders, payloadHash}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, c.region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, s3Sha256Hex([]byte(canonicalRequest))}, "\n")
+	signingKey := s3HmacSha256([]byte("AWS4"+c.secretKey), date)
+	signingKey = s3HmacSha256(signingKey, c.region)
+	signingKey = s3HmacSha256(signingKey, "s3")
+	signingKey = s3HmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(s3HmacSha256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", c.accessKey, scope, signedHeaders, signature))
+	return req, nil
+}
+
+// s3EscapePath URI encodes every segment of the path as required by Signature Version 4
+func s3EscapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3Escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, s3Escape(k)+"="+s3Escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+func s3Sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func s3HmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -60,3 +60,19 @@ func (v stringRequiredIfValidator) ValidateInt64(ctx context.Context, req valida
 		}
 	}
 }
+
+func (v stringRequiredIfValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	var attr types.String
+	req.Config.GetAttribute(ctx, v.attribute, &attr)
+
+	requiredAttr := req.Path.String()
+
+	if attr.ValueString() == v.value {
+		if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || len(req.ConfigValue.Elements()) == 0 {
+			resp.Diagnostics.AddError(
+				"validation error: ",
+				fmt.Sprintf("Attribute %v is required, when %v is set to %v", requiredAttr, v.attribute, v.value),
+			)
+		}
+	}
+}